@@ -0,0 +1,123 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// ApiKeyPrefix starts every issued key so they are easy to spot in secret scanners
+const ApiKeyPrefix = "ibu"
+
+const (
+	ScopeProfileRead  = "profile:read"
+	ScopeProfileWrite = "profile:write"
+)
+
+// ApiKeyStore looks up issued api keys by their public id. Unknown ids fail
+// with an errs.Error of kind NotFound.
+type ApiKeyStore interface {
+	GetApiKey(ctx context.Context, keyId string) (model.ApiKey, error)
+}
+
+// GenerateApiKey returns a new plaintext key along with its public id and
+// the hash that is stored in place of the secret
+func GenerateApiKey() (plaintext, keyId, hash string, err error) {
+	id := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err = rand.Read(id); err != nil {
+		return
+	}
+	if _, err = rand.Read(secret); err != nil {
+		return
+	}
+
+	keyId = hex.EncodeToString(id)
+	plaintext = ApiKeyPrefix + "_" + keyId + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	hash = HashApiKey(plaintext)
+	return
+}
+
+// ApiKeyDisplayPrefix is the non secret part of a key shown back to users
+func ApiKeyDisplayPrefix(keyId string) string {
+	return ApiKeyPrefix + "_" + keyId
+}
+
+func HashApiKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseApiKey extracts the key id from a plaintext key
+func ParseApiKey(plaintext string) (string, error) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != ApiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", errors.New("malformed api key")
+	}
+	return parts[1], nil
+}
+
+// authenticateApiKey returns the user behind an api key and when the key was
+// created
+func (am *Middleware) authenticateApiKey(ctx context.Context, plaintext string) (model.User, time.Time, error) {
+	if am.ApiKeys == nil {
+		return model.User{}, time.Time{}, ErrApiKeysDisabled
+	}
+
+	keyId, err := ParseApiKey(plaintext)
+	if err != nil {
+		return model.User{}, time.Time{}, ErrInvalidApiKey.Wrap(err)
+	}
+
+	// only a missing key is a bad credential, a store that cannot answer
+	// must not make clients throw their keys away
+	key, err := am.ApiKeys.GetApiKey(ctx, keyId)
+	if isNotFound(err) {
+		return model.User{}, time.Time{}, ErrInvalidApiKey
+	}
+	if err != nil {
+		return model.User{}, time.Time{}, fmt.Errorf("could not read api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.SecretHash), []byte(HashApiKey(plaintext))) != 1 {
+		return model.User{}, time.Time{}, ErrInvalidApiKey
+	}
+
+	if !key.IsActive(time.Now()) {
+		return model.User{}, time.Time{}, ErrApiKeyInactive
+	}
+
+	return model.User{
+		Id:         key.UserId,
+		Email:      key.Email,
+		OrgId:      key.OrgId,
+		ApiKeyId:   key.KeyId,
+		Scopes:     key.Scopes,
+		AuthMethod: model.AuthMethodApiKey,
+	}, key.CreatedAt, nil
+}
@@ -0,0 +1,202 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errTestApiKeyNotFound = errs.New(errs.NotFound, "API_KEY_NOT_FOUND", "api key not found")
+
+type MockApiKeyStore struct {
+	keys map[string]model.ApiKey
+	err  error
+}
+
+func (m *MockApiKeyStore) GetApiKey(ctx context.Context, keyId string) (model.ApiKey, error) {
+	if m.err != nil {
+		return model.ApiKey{}, m.err
+	}
+	key, ok := m.keys[keyId]
+	if !ok {
+		return model.ApiKey{}, errTestApiKeyNotFound
+	}
+	return key, nil
+}
+
+func newTestApiKey(t *testing.T, expiresAt time.Time) (string, *MockApiKeyStore) {
+	t.Helper()
+	plaintext, keyId, hash, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plaintext, &MockApiKeyStore{keys: map[string]model.ApiKey{
+		keyId: {
+			KeyId:      keyId,
+			UserId:     "user-1",
+			Email:      "user@coinbase.com",
+			SecretHash: hash,
+			Scopes:     []string{ScopeProfileRead},
+			ExpiresAt:  expiresAt,
+		},
+	}}
+}
+
+func callWithAuthorization(aw Middleware, authorization string) (model.User, error) {
+	var authed model.User
+	md := metadata.Pairs("authorization", authorization)
+	ctx := metautils.NiceMD(md).ToIncoming(context.Background())
+	_, err := aw.InterceptorNew()(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: "/anyMethod"},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			authed = ctx.Value(model.UserCtxKey).(model.User)
+			return &struct{}{}, nil
+		})
+	return authed, err
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	plaintext, keyId, hash, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(plaintext, ApiKeyDisplayPrefix(keyId)+"_") {
+		t.Fatalf("expected key to start with its prefix, got %s", plaintext)
+	}
+	if strings.Contains(hash, plaintext) || hash != HashApiKey(plaintext) {
+		t.Fatal("expected stored hash to be derived from the key")
+	}
+
+	parsed, err := ParseApiKey(plaintext)
+	if err != nil || parsed != keyId {
+		t.Fatalf("expected to parse key id %s, got %s - %v", keyId, parsed, err)
+	}
+}
+
+func TestParseApiKeyMalformed(t *testing.T) {
+	for _, key := range []string{"", "nope", "abc_123_secret", "ibu__secret", "ibu_123_"} {
+		if _, err := ParseApiKey(key); err == nil {
+			t.Fatalf("expected error for %q", key)
+		}
+	}
+}
+
+func TestMiddlewareApiKey(t *testing.T) {
+	plaintext, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+	authed, err := callWithAuthorization(aw, "ApiKey "+plaintext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if authed.Id != "user-1" || authed.Email != "user@coinbase.com" {
+		t.Fatalf("expected api key owner in context, got %+v", authed)
+	}
+	if len(authed.Scopes) != 1 || authed.Scopes[0] != ScopeProfileRead {
+		t.Fatalf("expected key scopes in context, got %v", authed.Scopes)
+	}
+}
+
+func TestMiddlewareApiKeyWrongSecret(t *testing.T) {
+	plaintext, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+	_, err := callWithAuthorization(aw, "ApiKey "+plaintext+"x")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestMiddlewareApiKeyUnknown(t *testing.T) {
+	_, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+	plaintext, _, _, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = callWithAuthorization(aw, "ApiKey "+plaintext); !errors.Is(err, ErrInvalidApiKey) {
+		t.Fatalf("expected invalid api key, got %v", err)
+	}
+}
+
+func TestMiddlewareApiKeyStoreFailure(t *testing.T) {
+	throttled := errs.New(errs.Throttled, "BACKEND_THROTTLED", "the backend is busy, try again")
+	cases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"throttled", throttled.Wrap(errors.New("ProvisionedThroughputExceededException")), codes.Unavailable},
+		{"timed out", fmt.Errorf("dynamodb could not getItem: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{"failed", errors.New("dynamodb could not getItem: connection reset"), codes.Internal},
+	}
+
+	for _, c := range cases {
+		plaintext, store := newTestApiKey(t, time.Now().Add(time.Hour))
+		store.err = c.err
+		aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+		_, err := callWithAuthorization(aw, "ApiKey "+plaintext)
+		if errors.Is(err, ErrInvalidApiKey) || errs.ToStatus(err).Code() != c.code {
+			t.Fatalf("%s: expected %v rather than a bad credential, got %v", c.name, c.code, err)
+		}
+	}
+}
+
+func TestMiddlewareApiKeyExpired(t *testing.T) {
+	plaintext, store := newTestApiKey(t, time.Now().Add(-time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+	_, err := callWithAuthorization(aw, "ApiKey "+plaintext)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestMiddlewareApiKeyDisabled(t *testing.T) {
+	plaintext, _ := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}}
+
+	_, err := callWithAuthorization(aw, "ApiKey "+plaintext)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+}
+
+func TestMiddlewareBearerStillWorksWithApiKeys(t *testing.T) {
+	_, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store}
+
+	if _, err := callWithAuthorization(aw, "bearer goodToken"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
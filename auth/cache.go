@@ -0,0 +1,192 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultLookupTimeout bounds a GetUser call shared by concurrent requests
+const defaultLookupTimeout = 5 * time.Second
+
+// tokenCacheMetrics is published under /debug/vars as auth_token_cache
+var tokenCacheMetrics = expvar.NewMap("auth_token_cache")
+
+type cacheEntry struct {
+	key       string
+	user      *cognitoidentityprovider.GetUserOutput
+	err       error
+	expiresAt time.Time
+}
+
+// TokenCache wraps an AuthClient and remembers GetUser results per token so
+// the gateway fanning one page load out into several calls costs a single
+// Cognito round trip. Rejections are remembered briefly too.
+type TokenCache struct {
+	next        AuthClient
+	size        int
+	ttl         time.Duration
+	negativeTtl time.Duration
+	// lookupTimeout bounds the shared GetUser call, which outlives the
+	// request that started it
+	lookupTimeout time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	group   singleflight.Group
+}
+
+// NewTokenCache caches up to size tokens for at most ttl, never past the
+// token's own expiry, and rejected tokens for negativeTtl
+func NewTokenCache(next AuthClient, size int, ttl, negativeTtl time.Duration) *TokenCache {
+	return &TokenCache{
+		next:          next,
+		size:          size,
+		ttl:           ttl,
+		negativeTtl:   negativeTtl,
+		lookupTimeout: defaultLookupTimeout,
+		now:           time.Now,
+		entries:       make(map[string]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+func (c *TokenCache) GetUser(
+	ctx context.Context,
+	params *cognitoidentityprovider.GetUserInput,
+	optFns ...func(*cognitoidentityprovider.Options),
+) (*cognitoidentityprovider.GetUserOutput, error) {
+	if params == nil || params.AccessToken == nil {
+		return c.next.GetUser(ctx, params, optFns...)
+	}
+
+	// raw tokens are credentials, never keep them around as map keys
+	sum := sha256.Sum256([]byte(*params.AccessToken))
+	key := hex.EncodeToString(sum[:])
+
+	if entry, ok := c.get(key); ok {
+		tokenCacheMetrics.Add("hits", 1)
+		return entry.user, entry.err
+	}
+	tokenCacheMetrics.Add("misses", 1)
+
+	// the call is shared, so the request that happens to start it must not
+	// cancel it for the others. It runs detached under its own timeout and
+	// each caller stops waiting when its own context ends.
+	result := c.group.DoChan(key, func() (interface{}, error) {
+		lookupCtx, cancel := context.WithTimeout(detachedContext{ctx}, c.lookupTimeout)
+		defer cancel()
+
+		user, err := c.next.GetUser(lookupCtx, params, optFns...)
+		c.store(key, *params.AccessToken, user, err)
+		return user, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*cognitoidentityprovider.GetUserOutput), nil
+	}
+}
+
+// detachedContext keeps the values of its parent, such as the request's
+// logger, without its deadline or cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
+
+func (c *TokenCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return entry, true
+}
+
+func (c *TokenCache) store(key, token string, user *cognitoidentityprovider.GetUserOutput, err error) {
+	now := c.now()
+	expiresAt := now.Add(c.ttl)
+
+	if err != nil {
+		// only cache Cognito's verdict on the token, not outages or throttling
+		var notAuthorized *types.NotAuthorizedException
+		if !errors.As(err, &notAuthorized) || c.negativeTtl <= 0 {
+			return
+		}
+		expiresAt = now.Add(c.negativeTtl)
+	} else if claims, parseErr := parseUnverifiedClaims(token); parseErr == nil && claims.ExpiresAt > 0 {
+		if exp := time.Unix(claims.ExpiresAt, 0); exp.Before(expiresAt) {
+			expiresAt = exp
+		}
+	}
+
+	if !now.Before(expiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, user: user, err: err, expiresAt: expiresAt})
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		tokenCacheMetrics.Add("evictions", 1)
+	}
+}
+
+func (c *TokenCache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
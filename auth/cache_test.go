@@ -0,0 +1,213 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+type CountingCognito struct {
+	calls   int32
+	release chan struct{}
+}
+
+func (m *CountingCognito) GetUser(
+	ctx context.Context,
+	params *cognitoidentityprovider.GetUserInput,
+	optFns ...func(*cognitoidentityprovider.Options),
+) (*cognitoidentityprovider.GetUserOutput, error) {
+	atomic.AddInt32(&m.calls, 1)
+	if m.release != nil {
+		select {
+		case <-m.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	switch *params.AccessToken {
+	case "revokedToken":
+		return nil, &types.NotAuthorizedException{Message: aws.String("Access Token has been revoked")}
+	case "throttledToken":
+		return nil, &types.TooManyRequestsException{Message: aws.String("Rate exceeded")}
+	}
+	return &cognitoidentityprovider.GetUserOutput{
+		UserAttributes: []types.AttributeType{{Name: aws.String("sub"), Value: params.AccessToken}},
+	}, nil
+}
+
+func getUser(c *TokenCache, token string) (*cognitoidentityprovider.GetUserOutput, error) {
+	return c.GetUser(context.Background(), &cognitoidentityprovider.GetUserInput{AccessToken: aws.String(token)})
+}
+
+func TestTokenCacheHit(t *testing.T) {
+	cognito := &CountingCognito{}
+	c := NewTokenCache(cognito, 10, time.Minute, time.Second)
+
+	for i := 0; i < 3; i++ {
+		user, err := getUser(c, "goodToken")
+		if err != nil || *user.UserAttributes[0].Value != "goodToken" {
+			t.Fatalf("unexpected result: %v %v", user, err)
+		}
+	}
+	if cognito.calls != 1 {
+		t.Fatalf("expected a single cognito call, got %d", cognito.calls)
+	}
+}
+
+func TestTokenCacheTtlCappedAtExpiry(t *testing.T) {
+	cognito := &CountingCognito{}
+	c := NewTokenCache(cognito, 10, time.Hour, time.Second)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "exp": now.Add(time.Minute).Unix()})
+	_, _ = getUser(c, token)
+
+	now = now.Add(30 * time.Second)
+	_, _ = getUser(c, token)
+	if cognito.calls != 1 {
+		t.Fatalf("expected cached result before expiry, got %d calls", cognito.calls)
+	}
+
+	now = now.Add(time.Minute)
+	_, _ = getUser(c, token)
+	if cognito.calls != 2 {
+		t.Fatalf("expected token expiry to end caching, got %d calls", cognito.calls)
+	}
+}
+
+func TestTokenCacheNegative(t *testing.T) {
+	cognito := &CountingCognito{}
+	c := NewTokenCache(cognito, 10, time.Minute, 5*time.Second)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		var notAuthorized *types.NotAuthorizedException
+		if _, err := getUser(c, "revokedToken"); !errors.As(err, &notAuthorized) {
+			t.Fatalf("expected not authorized, got %v", err)
+		}
+	}
+	if cognito.calls != 1 {
+		t.Fatalf("expected rejection to be cached, got %d calls", cognito.calls)
+	}
+
+	now = now.Add(10 * time.Second)
+	_, _ = getUser(c, "revokedToken")
+	if cognito.calls != 2 {
+		t.Fatalf("expected negative entry to expire, got %d calls", cognito.calls)
+	}
+
+	// throttling says nothing about the token, so it is not remembered
+	_, _ = getUser(c, "throttledToken")
+	_, _ = getUser(c, "throttledToken")
+	if cognito.calls != 4 {
+		t.Fatalf("expected throttling not to be cached, got %d calls", cognito.calls)
+	}
+}
+
+func TestTokenCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cognito := &CountingCognito{}
+	c := NewTokenCache(cognito, 2, time.Minute, time.Second)
+
+	_, _ = getUser(c, "a")
+	_, _ = getUser(c, "b")
+	_, _ = getUser(c, "a")
+	_, _ = getUser(c, "c")
+	if len(c.entries) != 2 {
+		t.Fatalf("expected cache to stay bounded, got %d entries", len(c.entries))
+	}
+
+	_, _ = getUser(c, "a")
+	if cognito.calls != 3 {
+		t.Fatalf("expected recently used token to survive eviction, got %d calls", cognito.calls)
+	}
+	_, _ = getUser(c, "b")
+	if cognito.calls != 4 {
+		t.Fatalf("expected least recently used token to be evicted, got %d calls", cognito.calls)
+	}
+}
+
+func TestTokenCacheCollapsesConcurrentLookups(t *testing.T) {
+	cognito := &CountingCognito{release: make(chan struct{})}
+	c := NewTokenCache(cognito, 10, time.Minute, time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := getUser(c, "goodToken"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// give every goroutine time to join the in flight lookup
+	time.Sleep(50 * time.Millisecond)
+	close(cognito.release)
+	wg.Wait()
+
+	if cognito.calls != 1 {
+		t.Fatalf("expected concurrent lookups to share one call, got %d", cognito.calls)
+	}
+}
+
+func TestTokenCacheLeaderCancelDoesNotFailFollowers(t *testing.T) {
+	cognito := &CountingCognito{release: make(chan struct{})}
+	c := NewTokenCache(cognito, 10, time.Minute, time.Second)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetUser(leaderCtx, &cognitoidentityprovider.GetUserInput{AccessToken: aws.String("goodToken")})
+		leaderErr <- err
+	}()
+	for atomic.LoadInt32(&cognito.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := getUser(c, "goodToken")
+		followerErr <- err
+	}()
+	// give the follower time to join the in flight lookup
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the leader to stop waiting, got %v", err)
+	}
+
+	close(cognito.release)
+	if err := <-followerErr; err != nil {
+		t.Fatalf("expected the follower to get the shared result, got %v", err)
+	}
+	if _, err := getUser(c, "goodToken"); err != nil || cognito.calls != 1 {
+		t.Fatalf("expected the shared result to be cached, got %d calls, %v", cognito.calls, err)
+	}
+}
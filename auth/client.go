@@ -17,6 +17,8 @@
 package auth
 
 import (
+	"context"
+
 	awsConfig "github.com/aws/aws-sdk-go-v2/aws"
 	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
@@ -26,13 +28,26 @@ type CognitoClient struct {
 	AppClientId string
 	UserPoolId  string
 	*cip.Client
+	// Admin writes user attributes and signs users out, kept apart from the
+	// embedded client so it can be replaced in tests
+	Admin AdminClient
+}
+
+// AdminClient is the part of the Cognito admin API used to read and write
+// user attributes and sign users out
+type AdminClient interface {
+	AdminGetUser(ctx context.Context, params *cip.AdminGetUserInput, optFns ...func(*cip.Options)) (*cip.AdminGetUserOutput, error)
+	AdminUpdateUserAttributes(ctx context.Context, params *cip.AdminUpdateUserAttributesInput, optFns ...func(*cip.Options)) (*cip.AdminUpdateUserAttributesOutput, error)
+	AdminUserGlobalSignOut(ctx context.Context, params *cip.AdminUserGlobalSignOutInput, optFns ...func(*cip.Options)) (*cip.AdminUserGlobalSignOutOutput, error)
 }
 
 func InitAuth(a *config.AppConfig, cfg awsConfig.Config) *CognitoClient {
+	client := cip.NewFromConfig(cfg)
 
 	return &CognitoClient{
-		a.ClientId,
-		a.UserPoolId,
-		cip.NewFromConfig(cfg),
+		AppClientId: a.ClientId,
+		UserPoolId:  a.UserPoolId,
+		Client:      client,
+		Admin:       client,
 	}
 }
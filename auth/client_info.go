@@ -0,0 +1,144 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TrustedProxies are the peers allowed to forward the address of the client
+// they received a call from in x-forwarded-for, such as the grpc-gateway
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies reads a comma separated list of addresses and CIDR
+// ranges, e.g. 127.0.0.1,10.0.0.0/8
+func ParseTrustedProxies(s string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("trusted proxy %q is not an address or CIDR range", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an address or CIDR range", entry)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+func (t TrustedProxies) trusts(ip net.IP) bool {
+	for _, network := range t {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientInfoInterceptor resolves the caller's address and user agent once,
+// ahead of everything that limits or records callers by address
+func ClientInfoInterceptor(proxies TrustedProxies) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, model.ClientInfoCtxKey, resolveClientInfo(ctx, proxies)), req)
+	}
+}
+
+// ClientInfoFromContext returns the client info resolved by
+// ClientInfoInterceptor. Without it only the connection's address is used.
+func ClientInfoFromContext(ctx context.Context) model.ClientInfo {
+	if info, ok := ctx.Value(model.ClientInfoCtxKey).(model.ClientInfo); ok {
+		return info
+	}
+	return resolveClientInfo(ctx, nil)
+}
+
+// resolveClientInfo reads the caller's address from the connection. Only a
+// trusted proxy may name the client behind it, x-forwarded-for sent by
+// anyone else is ignored since the caller chooses it freely.
+func resolveClientInfo(ctx context.Context, proxies TrustedProxies) model.ClientInfo {
+	var info model.ClientInfo
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IpAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IpAddress); err == nil {
+			info.IpAddress = host
+		}
+	}
+	if proxies.trusts(net.ParseIP(info.IpAddress)) {
+		info.IpAddress = proxies.forwardedAddress(md.Get("x-forwarded-for"), info.IpAddress)
+	}
+
+	if ua := firstValue(md, "grpcgateway-user-agent"); ua != "" {
+		info.UserAgent = ua
+	} else {
+		info.UserAgent = firstValue(md, "user-agent")
+	}
+
+	return info
+}
+
+// forwardedAddress walks x-forwarded-for back from the hop nearest to us.
+// Each proxy appends the address it received the call from, so the client
+// is the first hop that is not itself a trusted proxy.
+func (t TrustedProxies) forwardedAddress(values []string, proxy string) string {
+	var hops []string
+	for _, value := range values {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	address := proxy
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		address = ip.String()
+		if !t.trusts(ip) {
+			break
+		}
+	}
+	return address
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
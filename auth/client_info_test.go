@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func fromAddress(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}})
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("127.0.0.1, ::1,10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ip := range []string{"127.0.0.1", "::1", "10.1.2.3"} {
+		if !proxies.trusts(net.ParseIP(ip)) {
+			t.Errorf("expected %s to be trusted", ip)
+		}
+	}
+	if proxies.trusts(net.ParseIP("192.0.2.1")) {
+		t.Error("expected 192.0.2.1 not to be trusted")
+	}
+
+	if _, err := ParseTrustedProxies("gateway"); err == nil {
+		t.Error("expected an error for a hostname")
+	}
+}
+
+func TestClientInfoInterceptor(t *testing.T) {
+	proxies, _ := ParseTrustedProxies("127.0.0.1,10.0.0.0/8")
+
+	cases := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{"direct caller", "192.0.2.1", nil, "192.0.2.1"},
+		{"direct caller forging", "192.0.2.1", []string{"198.51.100.1"}, "192.0.2.1"},
+		{"through the gateway", "127.0.0.1", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"client forging behind the gateway", "127.0.0.1", []string{"203.0.113.9, 198.51.100.1"}, "198.51.100.1"},
+		{"through a chain of proxies", "127.0.0.1", []string{"198.51.100.1, 10.0.0.5"}, "198.51.100.1"},
+		{"gateway without a client", "127.0.0.1", nil, "127.0.0.1"},
+		{"garbage after a proxy", "127.0.0.1", []string{"not-an-address"}, "127.0.0.1"},
+	}
+
+	for _, c := range cases {
+		ctx := fromAddress(c.peer)
+		if c.forwarded != nil {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", c.forwarded[0]))
+		}
+
+		var got model.ClientInfo
+		ClientInfoInterceptor(proxies)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			got = ClientInfoFromContext(ctx)
+			return nil, nil
+		})
+		if got.IpAddress != c.want {
+			t.Errorf("%s: expected %s, got %s", c.name, c.want, got.IpAddress)
+		}
+	}
+}
+
+func TestClientInfoWithoutInterceptorIgnoresForwardedFor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(fromAddress("127.0.0.1"), metadata.Pairs("x-forwarded-for", "198.51.100.1"))
+	if got := ClientInfoFromContext(ctx).IpAddress; got != "127.0.0.1" {
+		t.Fatalf("expected the peer address, got %s", got)
+	}
+}
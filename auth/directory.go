@@ -0,0 +1,90 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// SetUserOrg mirrors the user's membership in their org custom attribute, an
+// empty orgId clears it
+func (c *CognitoClient) SetUserOrg(ctx context.Context, userId, orgId string) error {
+	return c.UpdateUserAttributes(ctx, userId, map[string]string{OrgIdAttribute: orgId})
+}
+
+// UpdateUserAttributes overwrites the given attributes of a user in the pool
+func (c *CognitoClient) UpdateUserAttributes(ctx context.Context, userId string, attributes map[string]string) error {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	userAttributes := make([]types.AttributeType, 0, len(names))
+	for _, name := range names {
+		userAttributes = append(userAttributes, types.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(attributes[name]),
+		})
+	}
+
+	if _, err := c.Admin.AdminUpdateUserAttributes(ctx, &cip.AdminUpdateUserAttributesInput{
+		UserPoolId:     aws.String(c.UserPoolId),
+		Username:       aws.String(userId),
+		UserAttributes: userAttributes,
+	}); err != nil {
+		return fmt.Errorf("cognito could not update user attributes: %w", err)
+	}
+	return nil
+}
+
+// UserEmail reads the user's email from the pool, for callers whose token
+// does not carry it
+func (c *CognitoClient) UserEmail(ctx context.Context, userId string) (string, error) {
+	user, err := c.Admin.AdminGetUser(ctx, &cip.AdminGetUserInput{
+		UserPoolId: aws.String(c.UserPoolId),
+		Username:   aws.String(userId),
+	})
+	if err != nil {
+		return "", fmt.Errorf("cognito could not read user: %w", err)
+	}
+	for _, attr := range user.UserAttributes {
+		if aws.ToString(attr.Name) == EmailAttribute {
+			return aws.ToString(attr.Value), nil
+		}
+	}
+	return "", nil
+}
+
+// SignOutUser revokes every refresh token of the user in the pool, so no new
+// access tokens can be minted. Access tokens already issued stay valid at
+// Cognito until they expire.
+func (c *CognitoClient) SignOutUser(ctx context.Context, userId string) error {
+	if _, err := c.Admin.AdminUserGlobalSignOut(ctx, &cip.AdminUserGlobalSignOutInput{
+		UserPoolId: aws.String(c.UserPoolId),
+		Username:   aws.String(userId),
+	}); err != nil {
+		return fmt.Errorf("cognito could not sign out user: %w", err)
+	}
+	return nil
+}
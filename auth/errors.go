@@ -0,0 +1,50 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+)
+
+var (
+	ErrMissingCredentials = errs.New(errs.Unauthenticated, "MISSING_CREDENTIALS", "missing bearer token or api key")
+	ErrInvalidToken       = errs.New(errs.Unauthenticated, "INVALID_TOKEN", "invalid token")
+	ErrSessionRevoked     = errs.New(errs.Unauthenticated, "SESSION_REVOKED", "session has been revoked")
+	ErrTokenRevoked       = errs.New(errs.Unauthenticated, "TOKEN_REVOKED", "token has been revoked")
+	ErrApiKeysDisabled    = errs.New(errs.Unauthenticated, "API_KEYS_DISABLED", "api key authentication is not enabled")
+	ErrInvalidApiKey      = errs.New(errs.Unauthenticated, "INVALID_API_KEY", "invalid api key")
+	ErrApiKeyInactive     = errs.New(errs.Unauthenticated, "API_KEY_INACTIVE", "api key is expired or revoked")
+	ErrStepUpRequired     = errs.New(errs.Unauthenticated, "STEP_UP_REQUIRED", "sign in again to change these fields")
+
+	ErrUserRequired         = errs.New(errs.PermissionDenied, "USER_REQUIRED", "method requires a user")
+	ErrMethodNotAvailable   = errs.New(errs.PermissionDenied, "METHOD_NOT_AVAILABLE", "method is not available")
+	ErrMissingScope         = errs.New(errs.PermissionDenied, "MISSING_SCOPE", "missing required scope")
+	ErrImpersonationOff     = errs.New(errs.PermissionDenied, "IMPERSONATION_DISABLED", "impersonation is not enabled")
+	ErrApiKeyImpersonation  = errs.New(errs.PermissionDenied, "IMPERSONATION_DENIED", "api keys cannot impersonate")
+	ErrNotImpersonable      = errs.New(errs.PermissionDenied, "IMPERSONATION_DENIED", "method is not available while impersonating")
+	ErrInvalidGrant         = errs.New(errs.PermissionDenied, "INVALID_IMPERSONATION_GRANT", "invalid impersonation grant")
+	ErrImpersonationExpired = errs.New(errs.PermissionDenied, "IMPERSONATION_GRANT_INACTIVE", "impersonation grant is expired or revoked")
+)
+
+// isNotFound reports whether a store failed because the item does not exist,
+// rather than because it could not answer
+func isNotFound(err error) bool {
+	var domainErr *errs.Error
+	return errors.As(err, &domainErr) && domainErr.Kind == errs.NotFound
+}
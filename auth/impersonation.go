@@ -0,0 +1,84 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// ImpersonationHeader carries the id of the grant a support engineer is
+// acting under
+const ImpersonationHeader = "x-impersonation-grant"
+
+// ImpersonationStore looks up impersonation grants by id
+type ImpersonationStore interface {
+	GetImpersonationGrant(ctx context.Context, grantId string) (model.ImpersonationGrant, error)
+}
+
+// impersonableMethods are the only methods honored under a grant, support
+// sees what the customer sees but cannot change anything on their behalf
+var impersonableMethods = map[string]bool{
+	"/pkg.pbs.profile.v1.ProfileService/ReadProfile":    true,
+	"/pkg.pbs.profile.v1.ProfileService/ListConsents":   true,
+	"/pkg.pbs.profile.v1.ProfileService/ListMySessions": true,
+	"/pkg.pbs.profile.v1.ProfileService/ListApiKeys":    true,
+	"/pkg.pbs.profile.v1.ProfileService/ListMembers":    true,
+}
+
+// impersonationScopes are held by every impersonated principal, none of the
+// actor's scopes carry over and the impersonable methods only read
+var impersonationScopes = []string{ScopeProfileRead}
+
+// impersonate swaps the authenticated actor for the subject of the grant,
+// keeping the actor alongside so neither identity is lost
+func (am *Middleware) impersonate(ctx context.Context, actor model.User, grantId, method string) (model.User, error) {
+	if am.Grants == nil {
+		return model.User{}, ErrImpersonationOff
+	}
+
+	if !impersonableMethods[method] {
+		return model.User{}, ErrNotImpersonable
+	}
+
+	grant, err := am.Grants.GetImpersonationGrant(ctx, grantId)
+	if err != nil || grant.ActorId != actor.Id {
+		return model.User{}, ErrInvalidGrant
+	}
+
+	if !grant.IsActive(time.Now()) {
+		return model.User{}, ErrImpersonationExpired
+	}
+
+	return model.User{
+		Id:                   grant.SubjectId,
+		Email:                grant.SubjectEmail,
+		OrgId:                grant.SubjectOrgId,
+		ActorId:              actor.Id,
+		ActorEmail:           actor.Email,
+		ImpersonationGrantId: grant.GrantId,
+		// the credential is still the actor's, but none of their groups
+		// or roles carry over to the customer
+		AuthMethod: actor.AuthMethod,
+		Scopes:     append([]string{}, impersonationScopes...),
+		ExpiresAt:  actor.ExpiresAt,
+		AuthTime:   actor.AuthTime,
+		Amr:        actor.Amr,
+	}, nil
+}
@@ -0,0 +1,139 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const readProfileMethod = "/pkg.pbs.profile.v1.ProfileService/ReadProfile"
+
+type MockImpersonationStore struct {
+	grants map[string]model.ImpersonationGrant
+}
+
+func (m *MockImpersonationStore) GetImpersonationGrant(ctx context.Context, grantId string) (model.ImpersonationGrant, error) {
+	grant, ok := m.grants[grantId]
+	if !ok {
+		return model.ImpersonationGrant{}, errors.New("impersonation grant not found")
+	}
+	return grant, nil
+}
+
+func newTestGrants(actorId string, expiresAt time.Time) *MockImpersonationStore {
+	return &MockImpersonationStore{grants: map[string]model.ImpersonationGrant{
+		"grant-1": {
+			GrantId:      "grant-1",
+			ActorId:      actorId,
+			SubjectId:    "customer-1",
+			SubjectOrgId: "org-2",
+			SubjectEmail: "customer@example.com",
+			ExpiresAt:    expiresAt,
+		},
+	}}
+}
+
+func callImpersonating(aw Middleware, method string, authorization string) (model.User, context.Context, error) {
+	var authed model.User
+	var handlerCtx context.Context
+	md := metadata.Pairs("authorization", authorization, ImpersonationHeader, "grant-1")
+	ctx := metautils.NiceMD(md).ToIncoming(context.Background())
+	_, err := aw.InterceptorNew()(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			authed = ctx.Value(model.UserCtxKey).(model.User)
+			handlerCtx = ctx
+			return &struct{}{}, nil
+		})
+	return authed, handlerCtx, err
+}
+
+func TestMiddlewareImpersonation(t *testing.T) {
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Grants: newTestGrants("user-1", time.Now().Add(time.Minute))}
+
+	authed, ctx, err := callImpersonating(aw, readProfileMethod, "bearer anyToken")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if authed.Id != "customer-1" || authed.OrgId != "org-2" || authed.Email != "customer@example.com" {
+		t.Fatalf("expected the subject in context, got %+v", authed)
+	}
+	if authed.ActorId != "user-1" || authed.Actor() != "user-1" || !authed.IsImpersonated() {
+		t.Fatalf("expected the actor to be kept, got %+v", authed)
+	}
+
+	fields := log.Extract(ctx).GetUnderneath().Data
+	if fields["actorId"] != "user-1" || fields["subjectId"] != "customer-1" || fields["impersonationGrantId"] != "grant-1" {
+		t.Fatalf("expected both identities on handler log lines, got %v", fields)
+	}
+}
+
+func TestMiddlewareImpersonationDenied(t *testing.T) {
+	cases := []struct {
+		name   string
+		aw     Middleware
+		method string
+	}{
+		{"disabled", Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}}, readProfileMethod},
+		{"mutating method", Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Grants: newTestGrants("user-1", time.Now().Add(time.Minute))}, "/pkg.pbs.profile.v1.ProfileService/UpdateProfile"},
+		{"other actor", Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Grants: newTestGrants("user-2", time.Now().Add(time.Minute))}, readProfileMethod},
+		{"expired", Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Grants: newTestGrants("user-1", time.Now().Add(-time.Minute))}, readProfileMethod},
+	}
+
+	for _, c := range cases {
+		if _, _, err := callImpersonating(c.aw, c.method, "bearer anyToken"); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("%s: expected PermissionDenied, got %v", c.name, err)
+		}
+	}
+}
+
+func TestMiddlewareApiKeyCannotImpersonate(t *testing.T) {
+	plaintext, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store, Grants: newTestGrants("user-1", time.Now().Add(time.Minute))}
+
+	if _, _, err := callImpersonating(aw, readProfileMethod, "ApiKey "+plaintext); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestImpersonationPassesScopePolicy(t *testing.T) {
+	policy := loadTestPolicy(t)
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Grants: newTestGrants("user-1", time.Now().Add(time.Minute))}
+
+	for method := range impersonableMethods {
+		md := metadata.Pairs("authorization", "bearer anyToken", ImpersonationHeader, "grant-1")
+		ctx := metautils.NiceMD(md).ToIncoming(context.Background())
+		_, err := aw.InterceptorNew()(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, callWithPolicy(policy, ctx, method)
+			})
+		if err != nil {
+			t.Fatalf("%s: expected an impersonated read to pass the policy, got %v", method, err)
+		}
+	}
+}
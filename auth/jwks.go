@@ -0,0 +1,178 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// minJwksRefresh stops tokens with made up key ids from turning every
+// request into a JWKS download
+const minJwksRefresh = time.Minute
+
+var ErrUnknownKey = errors.New("signing key not found in jwks")
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JwksCache holds the RSA signing keys published at a JWKS url, fetching
+// them again when asked for a key id it has not seen
+type JwksCache struct {
+	url     string
+	client  *http.Client
+	fetches singleflight.Group
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+	// attemptedAt and fetchErr belong to the last fetch, successful or not,
+	// so an unreachable endpoint is not retried by every request
+	attemptedAt time.Time
+	fetchErr    error
+}
+
+func NewJwksCache(url string, client *http.Client) *JwksCache {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &JwksCache{url: url, client: client}
+}
+
+// Key returns the public key for kid, refreshing the key set when kid is
+// unknown, for example right after the user pool rotates its keys
+func (c *JwksCache) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	c.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// concurrent lookups share one fetch, which no single caller can cancel
+	result := c.fetches.DoChan("jwks", func() (interface{}, error) {
+		return nil, c.refresh()
+	})
+	select {
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if key, ok = c.keys[kid]; !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// refresh fetches the key set unless the last attempt was too recent, in
+// which case that attempt's outcome stands. The keys are swapped in once
+// fetched, lookups of known keys never wait on the endpoint.
+func (c *JwksCache) refresh() error {
+	c.mu.RLock()
+	recent := !c.attemptedAt.IsZero() && time.Since(c.attemptedAt) < minJwksRefresh
+	fetchErr := c.fetchErr
+	c.mu.RUnlock()
+	if recent {
+		return fetchErr
+	}
+
+	keys, err := c.fetch()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attemptedAt = time.Now()
+	c.fetchErr = err
+	if err == nil {
+		c.keys = keys
+	}
+	return err
+}
+
+func (c *JwksCache) fetch() (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequest(http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build jwks request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("could not decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := k.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("could not parse jwks key %s: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, errors.New("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	exponent := 0
+	for _, b := range e {
+		exponent = exponent<<8 | int(b)
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+}
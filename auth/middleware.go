@@ -18,26 +18,77 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
 	"github.com/coinbase-samples/ib-usermgr-go/model"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
-type AuthClient interface {
-	GetUser(ctx context.Context, params *cognitoidentityprovider.GetUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.GetUserOutput, error)
+// OrgIdAttribute is the Cognito custom attribute holding the user's organization
+const OrgIdAttribute = "custom:org_id"
+
+// SessionStore records authenticated sessions and reports their revocation.
+// GetSession fails with an errs.Error of kind NotFound for unknown sessions.
+type SessionStore interface {
+	TouchSession(ctx context.Context, session model.Session) (model.Session, error)
+	GetSession(ctx context.Context, userId, sessionId string) (model.Session, error)
+}
+
+// RevocationStore lists the revocations an admin has issued for a user
+type RevocationStore interface {
+	ListRevocations(ctx context.Context, userId string) ([]model.Revocation, error)
+}
+
+// MembershipStore reports the organization a user belongs to, an empty OrgId
+// meaning none
+type MembershipStore interface {
+	GetMembership(ctx context.Context, userId string) (model.Membership, error)
 }
 
 type Middleware struct {
-	Cip AuthClient
+	Identity    IdentityProvider
+	Sessions    SessionStore
+	Revocations RevocationStore
+	ApiKeys     ApiKeyStore
+	Grants      ImpersonationStore
+	// Memberships decides which organization a caller belongs to. Left nil
+	// the org claim of the credential is trusted, which only suits tests.
+	Memberships MembershipStore
+	// Services maps client certificate SANs to service names
+	Services map[string]string
+	// GroupRoles maps identity provider groups to the service roles their
+	// members hold
+	GroupRoles map[string][]string
+	// SessionTouchInterval is how often a request records its session, left
+	// zero every request does
+	SessionTouchInterval time.Duration
+	// MembershipCacheTtl is how long a member's organization is remembered,
+	// a removed member keeps it for at most that long
+	MembershipCacheTtl time.Duration
+	// RevocationCacheTtl is how long a user's revocations and whether a
+	// session is revoked are remembered, a revocation issued through another
+	// replica takes up to that long
+	RevocationCacheTtl time.Duration
+
+	touched         *ttlCache
+	orgs            *ttlCache
+	revoked         *ttlCache
+	revokedSessions *ttlCache
 }
 
 func (am *Middleware) InterceptorNew() grpc.UnaryServerInterceptor {
+	am.touched = newTtlCache(am.SessionTouchInterval, defaultTtlCacheSize)
+	am.orgs = newTtlCache(am.MembershipCacheTtl, defaultTtlCacheSize)
+	am.revoked = newTtlCache(am.RevocationCacheTtl, defaultTtlCacheSize)
+	am.revokedSessions = newTtlCache(am.RevocationCacheTtl, defaultTtlCacheSize)
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// allow health checks to pass through
 		if info.FullMethod == "/grpc.health.v1.Health/Check" || info.FullMethod == "/grpc.health.v1.Health/Watch" {
@@ -45,32 +96,246 @@ func (am *Middleware) InterceptorNew() grpc.UnaryServerInterceptor {
 		}
 		l := ctxlogrus.Extract(ctx)
 
+		service, isService := am.serviceFromPeer(ctx)
+		if isService {
+			ctx = withService(ctx, service)
+		}
+
+		// programmatic clients authenticate with "Authorization: ApiKey <key>"
+		if key, err := grpc_auth.AuthFromMD(ctx, "apikey"); err == nil {
+			authedUser, createdAt, err := am.authenticateApiKey(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			// a watermark also revokes the keys created before it, key
+			// times are truncated like token times
+			if err := am.checkRevocation(ctx, authedUser.Id, "", createdAt.Truncate(time.Second), l); err != nil {
+				return nil, err
+			}
+			// a key only works in the organization it was issued in
+			orgId, err := am.resolveOrg(ctx, authedUser, l)
+			if err != nil {
+				return nil, err
+			}
+			if orgId != authedUser.OrgId {
+				return nil, ErrApiKeyInactive
+			}
+			if metautils.ExtractIncoming(ctx).Get(ImpersonationHeader) != "" {
+				return nil, ErrApiKeyImpersonation
+			}
+			l.Debugf("adding api key user to context: %s - %s", authedUser.Id, authedUser.ApiKeyId)
+			return handler(withUser(ctx, authedUser), req)
+		}
+
 		token, err := grpc_auth.AuthFromMD(ctx, "bearer")
 		if err != nil {
-			return nil, fmt.Errorf("could not find bearer token from metadata: %w", err)
+			if isService {
+				if !serviceMethods[info.FullMethod] {
+					return nil, ErrUserRequired
+				}
+				l.Debugf("allowing service call: %s - %s", service.Name, info.FullMethod)
+				return handler(ctx, req)
+			}
+			return nil, ErrMissingCredentials
 		}
 
-		user, err := am.Cip.GetUser(ctx, &cognitoidentityprovider.GetUserInput{
-			AccessToken: aws.String(token),
-		})
+		authedUser, claims, err := am.authenticateBearer(ctx, token, l)
 		if err != nil {
-			return nil, fmt.Errorf("could not validate token: %w", err)
+			return nil, err
+		}
+
+		if authedUser.OrgId, err = am.resolveOrg(ctx, authedUser, l); err != nil {
+			return nil, err
 		}
 
-		ctx = addUserToContext(ctx, user, l)
-		return handler(ctx, req)
+		// refreshed tokens keep the auth_time of the sign in, so a watermark
+		// also cuts off tokens minted from it later. Tokens carrying neither
+		// time are treated as issued before any watermark.
+		signedInAt := claims.AuthTime
+		if signedInAt == 0 {
+			signedInAt = claims.IssuedAt
+		}
+		if err := am.checkRevocation(ctx, authedUser.Id, claims.TokenId, time.Unix(signedInAt, 0), l); err != nil {
+			return nil, err
+		}
+
+		if authedUser.SessionId, err = am.trackSession(ctx, claims, l); err != nil {
+			return nil, err
+		}
+
+		if grantId := metautils.ExtractIncoming(ctx).Get(ImpersonationHeader); grantId != "" {
+			authedUser, err = am.impersonate(ctx, authedUser, grantId, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			l.Infof("impersonating user: %s - %s - %s", authedUser.ActorId, authedUser.Id, authedUser.ImpersonationGrantId)
+		}
+
+		l.Debugf("adding user to context: %s", authedUser.Id)
+		return handler(withUser(ctx, authedUser), req)
+	}
+}
+
+// authenticateBearer resolves the user behind a bearer token with the
+// configured identity provider
+func (am *Middleware) authenticateBearer(ctx context.Context, token string, l *logrus.Entry) (model.User, TokenClaims, error) {
+	authedUser, claims, err := am.Identity.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			l.Debugf("rejecting bearer token: %v", err)
+			return model.User{}, claims, ErrInvalidToken
+		}
+		return model.User{}, claims, err
+	}
+	authedUser.AuthMethod = model.AuthMethodBearer
+	authedUser.Roles = am.rolesFor(authedUser.Groups)
+	return authedUser, claims, nil
+}
+
+// resolveOrg returns the organization recorded for the user. The org claim of
+// a token comes from a user attribute that users may be able to write
+// themselves, so it never decides which profiles a caller can reach. Only
+// memberships of an organization are cached, so a user who just joined is
+// seen right away.
+func (am *Middleware) resolveOrg(ctx context.Context, user model.User, l *logrus.Entry) (string, error) {
+	if am.Memberships == nil {
+		return user.OrgId, nil
+	}
+	if orgId, ok := am.orgs.get(user.Id); ok {
+		return orgId.(string), nil
+	}
+
+	membership, err := am.Memberships.GetMembership(ctx, user.Id)
+	if err != nil {
+		return "", fmt.Errorf("could not read membership: %w", err)
+	}
+	// a pending organization is left by accepting an invitation, which
+	// should be seen right away as well
+	if membership.OrgId != "" && !model.IsPendingOrg(membership.OrgId) {
+		am.orgs.set(user.Id, membership.OrgId)
+	}
+
+	if user.OrgId != "" && user.OrgId != membership.OrgId {
+		l.Warnf("ignoring org claim without a membership: %s - %s - %s", user.Id, user.OrgId, membership.OrgId)
 	}
+	return membership.OrgId, nil
 }
 
-func addUserToContext(ctx context.Context, user *cognitoidentityprovider.GetUserOutput, l *logrus.Entry) context.Context {
-	var authedUser = model.User{}
-	for _, attr := range user.UserAttributes {
-		if *attr.Name == "sub" {
-			authedUser.Id = *attr.Value
-		} else if *attr.Name == "email" {
-			authedUser.Email = *attr.Value
+// rolesFor returns the roles granted by membership in groups
+func (am *Middleware) rolesFor(groups []string) []string {
+	var roles []string
+	for _, group := range groups {
+		for _, role := range am.GroupRoles[group] {
+			if !model.HasRole(roles, role) {
+				roles = append(roles, role)
+			}
 		}
 	}
-	l.Debugf("adding user to context: %s", authedUser.Id)
+	return roles
+}
+
+// checkRevocation rejects credentials revoked in this service, by their
+// token id or by when they were issued. Cognito keeps honoring an access
+// token until it expires, so revocations are checked on every request rather
+// than relied on at the identity provider. They are remembered for
+// RevocationCacheTtl.
+func (am *Middleware) checkRevocation(ctx context.Context, userId, tokenId string, issuedAt time.Time, l *logrus.Entry) error {
+	if am.Revocations == nil {
+		return nil
+	}
+
+	var revocations []model.Revocation
+	if cached, ok := am.revoked.get(userId); ok {
+		revocations = cached.([]model.Revocation)
+	} else {
+		var err error
+		if revocations, err = am.Revocations.ListRevocations(ctx, userId); err != nil {
+			return fmt.Errorf("could not read revocations: %w", err)
+		}
+		am.revoked.set(userId, revocations)
+	}
+
+	for _, revocation := range revocations {
+		if revocation.Revokes(tokenId, issuedAt) {
+			l.Debugf("rejecting revoked token of: %s - %s", userId, revocation.RevocationId)
+			return ErrTokenRevoked
+		}
+	}
+
+	return nil
+}
+
+// trackSession records the request against the session the token belongs to
+// and rejects tokens whose session has been revoked. A session touched within
+// SessionTouchInterval is only read, and whether it is revoked is remembered
+// for RevocationCacheTtl like the user's other revocations.
+func (am *Middleware) trackSession(ctx context.Context, claims TokenClaims, l *logrus.Entry) (string, error) {
+	if am.Sessions == nil {
+		return "", nil
+	}
+
+	if claims.SessionId() == "" {
+		l.Debugf("not tracking session for token of: %s", claims.Subject)
+		return "", nil
+	}
+
+	key := claims.Subject + "#" + claims.SessionId()
+	revoked, known := am.revokedSessions.get(key)
+	if !known {
+		session, err := am.readSession(ctx, claims, key)
+		if err != nil {
+			return "", err
+		}
+		revoked = session.IsRevoked()
+		am.revokedSessions.set(key, revoked)
+	}
+
+	if revoked.(bool) {
+		return "", ErrSessionRevoked
+	}
+	return claims.SessionId(), nil
+}
+
+// readSession writes the session's last use once per SessionTouchInterval
+// and otherwise only reads it
+func (am *Middleware) readSession(ctx context.Context, claims TokenClaims, key string) (model.Session, error) {
+	if _, recent := am.touched.get(key); recent {
+		session, err := am.Sessions.GetSession(ctx, claims.Subject, claims.SessionId())
+		if err == nil {
+			return session, nil
+		}
+		if !isNotFound(err) {
+			return session, fmt.Errorf("could not read session: %w", err)
+		}
+		// the session went away since it was touched, record it again
+	}
+
+	client := ClientInfoFromContext(ctx)
+	session, err := am.Sessions.TouchSession(ctx, model.Session{
+		UserId:    claims.Subject,
+		SessionId: claims.SessionId(),
+		TokenId:   claims.TokenId,
+		IpAddress: client.IpAddress,
+		UserAgent: client.UserAgent,
+	})
+	if err != nil {
+		return session, fmt.Errorf("could not record session: %w", err)
+	}
+	am.touched.set(key, true)
+	return session, nil
+}
+
+// withUser adds the user to the context and tags every log line of the
+// request with both the real actor and the subject being acted on
+func withUser(ctx context.Context, authedUser model.User) context.Context {
+	fields := log.Fields{
+		"actorId":   authedUser.Actor(),
+		"subjectId": authedUser.Id,
+	}
+	if authedUser.IsImpersonated() {
+		fields["impersonationGrantId"] = authedUser.ImpersonationGrantId
+	}
+	ctxlogrus.AddFields(ctx, logrus.Fields(fields))
+	ctx = log.AddFields(ctx, fields)
 	return context.WithValue(ctx, model.UserCtxKey, authedUser)
 }
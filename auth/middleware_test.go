@@ -21,13 +21,20 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/coinbase-samples/ib-usermgr-go/cognitofake"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type MockCognito struct{}
@@ -51,7 +58,7 @@ func (m *MockCognito) GetUser(
 
 func TestMiddlewareEmptyContext(t *testing.T) {
 	cip := MockCognito{}
-	aw := Middleware{Cip: &cip}
+	aw := Middleware{Identity: &CognitoProvider{Client: &cip}}
 	intercepter := aw.InterceptorNew()
 	info := grpc.UnaryServerInfo{FullMethod: ""}
 	resp, err := intercepter(
@@ -73,7 +80,7 @@ func TestMiddlewareEmptyContext(t *testing.T) {
 
 func TestMiddlewareHealthCheckContext(t *testing.T) {
 	cip := MockCognito{}
-	aw := Middleware{Cip: &cip}
+	aw := Middleware{Identity: &CognitoProvider{Client: &cip}}
 	intercepter := aw.InterceptorNew()
 	info := grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
 	resp, err := intercepter(
@@ -95,7 +102,7 @@ func TestMiddlewareHealthCheckContext(t *testing.T) {
 
 func TestMiddlewareUserContext(t *testing.T) {
 	cip := MockCognito{}
-	aw := Middleware{Cip: &cip}
+	aw := Middleware{Identity: &CognitoProvider{Client: &cip}}
 	intercepter := aw.InterceptorNew()
 	info := grpc.UnaryServerInfo{FullMethod: "/anyMethod"}
 	md := metadata.Pairs("authorization", "bearer goodToken")
@@ -119,7 +126,7 @@ func TestMiddlewareUserContext(t *testing.T) {
 
 func TestMiddlewareBadUserContext(t *testing.T) {
 	cip := MockCognito{}
-	aw := Middleware{Cip: &cip}
+	aw := Middleware{Identity: &CognitoProvider{Client: &cip}}
 	intercepter := aw.InterceptorNew()
 	info := grpc.UnaryServerInfo{FullMethod: "/anyMethod"}
 	md := metadata.Pairs("authorization", "bearer badToken")
@@ -140,3 +147,403 @@ func TestMiddlewareBadUserContext(t *testing.T) {
 		t.Fatal("expected valid response")
 	}
 }
+
+type MockSubCognito struct{}
+
+func (m *MockSubCognito) GetUser(
+	ctx context.Context,
+	params *cognitoidentityprovider.GetUserInput,
+	optFns ...func(*cognitoidentityprovider.Options),
+) (*cognitoidentityprovider.GetUserOutput, error) {
+	return &cognitoidentityprovider.GetUserOutput{
+		UserAttributes: []types.AttributeType{
+			{Name: aws.String("sub"), Value: aws.String("user-1")},
+			{Name: aws.String(OrgIdAttribute), Value: aws.String("org-1")},
+		},
+	}, nil
+}
+
+type MockSessionStore struct {
+	touched []model.Session
+	revoked map[string]bool
+	reads   int
+}
+
+func (m *MockSessionStore) TouchSession(ctx context.Context, session model.Session) (model.Session, error) {
+	m.touched = append(m.touched, session)
+	return m.withRevocation(session), nil
+}
+
+func (m *MockSessionStore) GetSession(ctx context.Context, userId, sessionId string) (model.Session, error) {
+	m.reads++
+	for _, session := range m.touched {
+		if session.UserId == userId && session.SessionId == sessionId {
+			return m.withRevocation(session), nil
+		}
+	}
+	return model.Session{}, errs.New(errs.NotFound, "SESSION_NOT_FOUND", "session not found")
+}
+
+func (m *MockSessionStore) withRevocation(session model.Session) model.Session {
+	if m.revoked[session.SessionId] {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	return session
+}
+
+func TestMiddlewareTracksSession(t *testing.T) {
+	sessions := &MockSessionStore{}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Sessions: sessions}
+	intercepter := aw.InterceptorNew()
+	info := grpc.UnaryServerInfo{FullMethod: "/anyMethod"}
+	token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "jti": "jti-2", "origin_jti": "origin-1"})
+	md := metadata.Pairs("authorization", "bearer "+token, "user-agent", "grpc-go")
+	ctx := metautils.NiceMD(md).ToIncoming(fromAddress("192.0.2.1"))
+
+	var authed model.User
+	_, err := intercepter(ctx, &struct{}{}, &info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		authed = ctx.Value(model.UserCtxKey).(model.User)
+		return &struct{}{}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if authed.SessionId != "origin-1" {
+		t.Fatalf("expected session id on user, got %q", authed.SessionId)
+	}
+	if len(sessions.touched) != 1 {
+		t.Fatal("expected session to be recorded")
+	}
+	s := sessions.touched[0]
+	if s.UserId != "user-1" || s.TokenId != "jti-2" || s.IpAddress != "192.0.2.1" || s.UserAgent != "grpc-go" {
+		t.Fatalf("unexpected session recorded: %+v", s)
+	}
+}
+
+func TestMiddlewareRejectsRevokedSession(t *testing.T) {
+	sessions := &MockSessionStore{revoked: map[string]bool{"origin-1": true}}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Sessions: sessions}
+	intercepter := aw.InterceptorNew()
+	info := grpc.UnaryServerInfo{FullMethod: "/anyMethod"}
+	token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "jti": "jti-2", "origin_jti": "origin-1"})
+	md := metadata.Pairs("authorization", "bearer "+token)
+	ctx := metautils.NiceMD(md).ToIncoming(context.Background())
+
+	resp, err := intercepter(ctx, &struct{}{}, &info, func(_ context.Context, _ interface{}) (interface{}, error) {
+		return &struct{}{}, nil
+	})
+
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated for revoked session, got %v", err)
+	}
+	if resp != nil {
+		t.Fatal("expected nil response")
+	}
+}
+
+func TestMiddlewareTouchesSessionOncePerInterval(t *testing.T) {
+	sessions := &MockSessionStore{revoked: map[string]bool{}}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Sessions: sessions, SessionTouchInterval: time.Minute}
+	intercepter := aw.InterceptorNew()
+	now := time.Now()
+	aw.touched.now = func() time.Time { return now }
+
+	call := func(origin string) error {
+		token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "jti": "jti-2", "origin_jti": origin})
+		ctx := metautils.NiceMD(metadata.Pairs("authorization", "bearer "+token)).ToIncoming(context.Background())
+		_, err := intercepter(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: "/anyMethod"}, func(_ context.Context, _ interface{}) (interface{}, error) {
+			return &struct{}{}, nil
+		})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := call("origin-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(sessions.touched) != 1 {
+		t.Fatalf("expected one touch within the interval, got %d", len(sessions.touched))
+	}
+
+	if err := call("origin-2"); err != nil || len(sessions.touched) != 2 {
+		t.Fatalf("expected another session to be touched, got %d - %v", len(sessions.touched), err)
+	}
+
+	// between touches the session is read, so a revocation is noticed
+	// right away rather than once the interval passes
+	sessions.revoked["origin-1"] = true
+	if err := call("origin-1"); !errors.Is(err, ErrSessionRevoked) || len(sessions.touched) != 2 {
+		t.Fatalf("expected the revocation to be noticed without a touch, got %d - %v", len(sessions.touched), err)
+	}
+}
+
+func TestMiddlewareCachesSessionRevocation(t *testing.T) {
+	sessions := &MockSessionStore{revoked: map[string]bool{}}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Sessions: sessions, SessionTouchInterval: time.Minute, RevocationCacheTtl: 5 * time.Second}
+	intercepter := aw.InterceptorNew()
+	now := time.Now()
+	aw.touched.now = func() time.Time { return now }
+	aw.revokedSessions.now = func() time.Time { return now }
+
+	token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "jti": "jti-2", "origin_jti": "origin-1"})
+	call := func() error {
+		ctx := metautils.NiceMD(metadata.Pairs("authorization", "bearer "+token)).ToIncoming(context.Background())
+		_, err := intercepter(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: "/anyMethod"}, func(_ context.Context, _ interface{}) (interface{}, error) {
+			return &struct{}{}, nil
+		})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := call(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(sessions.touched) != 1 || sessions.reads != 0 {
+		t.Fatalf("expected one touch and no reads within the ttl, got %d and %d", len(sessions.touched), sessions.reads)
+	}
+
+	sessions.revoked["origin-1"] = true
+	now = now.Add(6 * time.Second)
+	if err := call(); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("expected the revocation to be noticed once the ttl passed, got %v", err)
+	}
+	if len(sessions.touched) != 1 || sessions.reads != 1 {
+		t.Fatalf("expected the session to be read rather than touched, got %d and %d", len(sessions.touched), sessions.reads)
+	}
+}
+
+type MockRevocationStore struct {
+	revocations map[string][]model.Revocation
+	reads       int
+}
+
+func (m *MockRevocationStore) ListRevocations(ctx context.Context, userId string) ([]model.Revocation, error) {
+	m.reads++
+	return m.revocations[userId], nil
+}
+
+func TestMiddlewareRejectsRevokedTokens(t *testing.T) {
+	watermark := time.Unix(1700000000, 0)
+	revocations := &MockRevocationStore{revocations: map[string][]model.Revocation{
+		"user-1": {
+			{UserId: "user-1", RevocationId: model.RevocationWatermark, NotBefore: &watermark},
+			{UserId: "user-1", RevocationId: model.TokenRevocationId("jti-revoked"), TokenId: "jti-revoked"},
+		},
+	}}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}, Revocations: revocations}
+
+	cases := []struct {
+		name    string
+		claims  map[string]interface{}
+		revoked bool
+	}{
+		{"signed in before watermark", map[string]interface{}{"jti": "jti-1", "iat": 1700000100, "auth_time": 1699999000}, true},
+		{"signed in at watermark", map[string]interface{}{"jti": "jti-1", "iat": 1700000000}, true},
+		{"signed in after watermark", map[string]interface{}{"jti": "jti-1", "iat": 1700000100, "auth_time": 1700000050}, false},
+		{"revoked token id", map[string]interface{}{"jti": "jti-revoked", "iat": 1700000100}, true},
+		{"no token times", map[string]interface{}{"jti": "jti-1"}, true},
+	}
+
+	for _, c := range cases {
+		c.claims["sub"] = "user-1"
+		_, err := callWithAuthorization(aw, "bearer "+makeTestToken(t, c.claims))
+		if c.revoked && !errors.Is(err, ErrTokenRevoked) {
+			t.Errorf("%s: expected revoked token, got %v", c.name, err)
+		}
+		if !c.revoked && err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		}
+	}
+}
+
+func TestMiddlewareRejectsApiKeysBeforeWatermark(t *testing.T) {
+	plaintext, store := newTestApiKey(t, time.Now().Add(time.Hour))
+	keyId, _ := ParseApiKey(plaintext)
+	watermark := time.Now().UTC().Truncate(time.Second)
+	revocations := &MockRevocationStore{revocations: map[string][]model.Revocation{}}
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockCognito{}}, ApiKeys: store, Revocations: revocations}
+
+	key := store.keys[keyId]
+	key.CreatedAt = watermark.Add(-time.Minute)
+	store.keys[keyId] = key
+	if _, err := callWithAuthorization(aw, "ApiKey "+plaintext); err != nil {
+		t.Fatalf("unexpected error before the revocation: %v", err)
+	}
+
+	revocations.revocations["user-1"] = []model.Revocation{{UserId: "user-1", RevocationId: model.RevocationWatermark, NotBefore: &watermark}}
+	if _, err := callWithAuthorization(aw, "ApiKey "+plaintext); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected a key created before the watermark to be revoked, got %v", err)
+	}
+
+	// a key created in the same second as the watermark may predate it
+	key.CreatedAt = watermark.Add(500 * time.Millisecond)
+	store.keys[keyId] = key
+	if _, err := callWithAuthorization(aw, "ApiKey "+plaintext); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected a key of the watermark's second to be revoked, got %v", err)
+	}
+
+	key.CreatedAt = watermark.Add(time.Second)
+	store.keys[keyId] = key
+	if _, err := callWithAuthorization(aw, "ApiKey "+plaintext); err != nil {
+		t.Fatalf("expected a key created after the watermark to work, got %v", err)
+	}
+}
+
+func TestMiddlewareCachesRevocations(t *testing.T) {
+	revocations := &MockRevocationStore{revocations: map[string][]model.Revocation{}}
+	aw := Middleware{
+		Identity:           &CognitoProvider{Client: &MockSubCognito{}},
+		Revocations:        revocations,
+		RevocationCacheTtl: time.Minute,
+	}
+	intercept := aw.InterceptorNew()
+	token := makeTestToken(t, map[string]interface{}{"sub": "user-1", "jti": "jti-1", "iat": time.Now().Unix()})
+	ctx := metautils.NiceMD(metadata.Pairs("authorization", "bearer "+token)).ToIncoming(context.Background())
+
+	for i := 0; i < 3; i++ {
+		if _, err := intercept(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: "/anyMethod"}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return &struct{}{}, nil
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if revocations.reads != 1 {
+		t.Fatalf("expected revocations to be read once, got %d reads", revocations.reads)
+	}
+}
+
+func TestMiddlewareAddsOrg(t *testing.T) {
+	aw := Middleware{Identity: &CognitoProvider{Client: &MockSubCognito{}}}
+
+	authed, err := callWithAuthorization(aw, "bearer anyToken")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if authed.OrgId != "org-1" {
+		t.Fatalf("expected org from cognito attribute, got %q", authed.OrgId)
+	}
+}
+
+type MockMembershipStore struct {
+	memberships map[string]string
+	reads       int
+}
+
+func (m *MockMembershipStore) GetMembership(ctx context.Context, userId string) (model.Membership, error) {
+	m.reads++
+	return model.Membership{UserId: userId, OrgId: m.memberships[userId]}, nil
+}
+
+func TestMiddlewareTakesOrgFromMembership(t *testing.T) {
+	// MockSubCognito claims org-1, as a user who rewrote their attribute would
+	memberships := &MockMembershipStore{memberships: map[string]string{"user-1": "org-2"}}
+	aw := Middleware{
+		Identity:           &CognitoProvider{Client: &MockSubCognito{}},
+		Memberships:        memberships,
+		MembershipCacheTtl: time.Minute,
+	}
+
+	authed, err := callWithAuthorization(aw, "bearer anyToken")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authed.OrgId != "org-2" {
+		t.Fatalf("expected org from membership, got %q", authed.OrgId)
+	}
+
+	delete(memberships.memberships, "user-1")
+	if authed, _ = callWithAuthorization(aw, "bearer anyToken"); authed.OrgId != "" {
+		t.Fatalf("expected no org without a membership, got %q", authed.OrgId)
+	}
+}
+
+func TestMiddlewareMapsGroupsToRoles(t *testing.T) {
+	aw := Middleware{
+		Identity: &CognitoProvider{Client: &MockSubCognito{}},
+		GroupRoles: map[string][]string{
+			"org-admins": {model.RoleAdmin},
+			"support":    {"support", model.RoleAdmin},
+		},
+	}
+	token := makeTestToken(t, map[string]interface{}{
+		"sub":            "user-1",
+		"username":       "bob",
+		"cognito:groups": []string{"org-admins", "support", "unmapped"},
+		"exp":            1700000600,
+		"auth_time":      1700000000,
+		"amr":            []string{"pwd", "mfa"},
+	})
+
+	authed, err := callWithAuthorization(aw, "bearer "+token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if authed.Username != "bob" || len(authed.Groups) != 3 {
+		t.Fatalf("expected username and groups from the token, got %+v", authed)
+	}
+	if len(authed.Roles) != 2 || !model.HasRole(authed.Roles, model.RoleAdmin) || !model.HasRole(authed.Roles, "support") {
+		t.Fatalf("expected admin and support roles, got %v", authed.Roles)
+	}
+	if authed.AuthMethod != model.AuthMethodBearer {
+		t.Fatalf("expected bearer auth method, got %q", authed.AuthMethod)
+	}
+	if !authed.ExpiresAt.Equal(time.Unix(1700000600, 0)) || !authed.AuthTime.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected token times: %v - %v", authed.ExpiresAt, authed.AuthTime)
+	}
+	// whatever added amr to a Cognito token, it does not say how the user
+	// signed in
+	if len(authed.Amr) != 0 {
+		t.Fatalf("expected amr of a cognito token to be ignored, got %v", authed.Amr)
+	}
+}
+
+func TestMiddlewareWithFakeCognito(t *testing.T) {
+	pool := cognitofake.New("local")
+	user, err := pool.AddUser(cognitofake.User{
+		Username:   "bob",
+		Attributes: map[string]string{"email": "bob@example.com", OrgIdAttribute: "org-1"},
+		Groups:     []string{"org-admins"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := pool.IssueToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := &MockSessionStore{}
+	aw := Middleware{
+		Identity:   &CognitoProvider{Client: pool},
+		Sessions:   sessions,
+		GroupRoles: map[string][]string{"org-admins": {model.RoleAdmin}},
+	}
+
+	authed, err := callWithAuthorization(aw, "bearer "+token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authed.Id != user.Sub || authed.OrgId != "org-1" || authed.Username != "bob" || authed.AuthTime.IsZero() {
+		t.Fatalf("unexpected user: %+v", authed)
+	}
+	if !model.HasRole(authed.Roles, model.RoleAdmin) || len(authed.Scopes) != 1 || authed.Scopes[0] != ScopeSignedIn {
+		t.Fatalf("expected admin role and sign in scope, got %v - %v", authed.Roles, authed.Scopes)
+	}
+	if len(sessions.touched) != 1 || authed.SessionId == "" {
+		t.Fatal("expected the session to be tracked")
+	}
+
+	client := &CognitoClient{UserPoolId: "local", Admin: pool}
+	if err := client.SignOutUser(context.Background(), user.Sub); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := callWithAuthorization(aw, "bearer "+token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected token to be rejected after sign out, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+}
+
+// OidcProvider accepts JWT access tokens from any OpenID Connect issuer,
+// verified against the issuer's JWKS. Opaque tokens are rejected: the
+// userinfo endpoint answers for a token issued to any client, so it cannot
+// tell whether the token was meant for this service.
+type OidcProvider struct {
+	verifier *JwtVerifier
+}
+
+// NewOidcProvider reads the issuer's discovery document to find its keys
+func NewOidcProvider(ctx context.Context, issuer, clientId string, client *http.Client) (*OidcProvider, error) {
+	if issuer == "" || clientId == "" {
+		return nil, fmt.Errorf("oidc provider requires an issuer and a client id")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	issuer = strings.TrimSuffix(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build discovery request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch oidc discovery: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch oidc discovery: unexpected status %d", resp.StatusCode)
+	}
+
+	var discovery oidcDiscovery
+	if err = json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("could not decode oidc discovery: %w", err)
+	}
+	// tokens name the issuer exactly as discovery does, anything else is misconfigured
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc discovery issuer %q does not match %q", discovery.Issuer, issuer)
+	}
+	if discovery.JwksUri == "" {
+		return nil, fmt.Errorf("oidc discovery for %s has no jwks_uri", issuer)
+	}
+
+	return &OidcProvider{
+		verifier: &JwtVerifier{
+			Issuer:   discovery.Issuer,
+			ClientId: clientId,
+			Keys:     NewJwksCache(discovery.JwksUri, client),
+		},
+	}, nil
+}
+
+func (p *OidcProvider) Authenticate(ctx context.Context, token string) (model.User, TokenClaims, error) {
+	if strings.Count(token, ".") != 2 {
+		return model.User{}, TokenClaims{}, fmt.Errorf("%w: opaque tokens are not accepted", ErrInvalidToken)
+	}
+	user, claims, err := p.verifier.Authenticate(ctx, token)
+	if err != nil {
+		return user, claims, err
+	}
+	user.Amr = claims.Amr
+	return user, claims, nil
+}
@@ -0,0 +1,111 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// IdentityProvider resolves the user behind a bearer token. Tokens the
+// provider rejects fail with an error wrapping ErrInvalidToken. The returned
+// claims are used for session tracking and may be empty.
+type IdentityProvider interface {
+	Authenticate(ctx context.Context, token string) (model.User, TokenClaims, error)
+}
+
+// AuthClient is the part of the Cognito client used to validate tokens
+type AuthClient interface {
+	GetUser(ctx context.Context, params *cognitoidentityprovider.GetUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.GetUserOutput, error)
+}
+
+// CognitoProvider validates tokens with a Cognito GetUser call
+type CognitoProvider struct {
+	Client AuthClient
+}
+
+func (p *CognitoProvider) Authenticate(ctx context.Context, token string) (model.User, TokenClaims, error) {
+	user, err := p.Client.GetUser(ctx, &cognitoidentityprovider.GetUserInput{
+		AccessToken: aws.String(token),
+	})
+	if err != nil {
+		var notAuthorized *types.NotAuthorizedException
+		if errors.As(err, &notAuthorized) {
+			return model.User{}, TokenClaims{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		return model.User{}, TokenClaims{}, fmt.Errorf("could not validate token: %w", err)
+	}
+
+	// Cognito accepted the token, so its claims can be trusted for the session
+	claims, _ := parseUnverifiedClaims(token)
+
+	authedUser := userFromCognito(user, "")
+	authedUser.Scopes = strings.Fields(claims.Scope)
+	claims.applyTo(&authedUser)
+	return authedUser, claims, nil
+}
+
+func userFromCognito(user *cognitoidentityprovider.GetUserOutput, sessionId string) model.User {
+	var authedUser = model.User{SessionId: sessionId, Username: aws.StringValue(user.Username)}
+	for _, attr := range user.UserAttributes {
+		if *attr.Name == "sub" {
+			authedUser.Id = *attr.Value
+		} else if *attr.Name == "email" {
+			authedUser.Email = *attr.Value
+		} else if *attr.Name == OrgIdAttribute {
+			authedUser.OrgId = *attr.Value
+		}
+	}
+	return authedUser
+}
+
+// NewIdentityProvider builds the provider selected by IDENTITY_PROVIDER
+func NewIdentityProvider(ctx context.Context, app *config.AppConfig, cognito AuthClient) (IdentityProvider, error) {
+	switch app.IdentityProvider {
+	case config.IdentityProviderCognito:
+		if app.VerifiesTokensLocally() {
+			return NewCognitoVerifier(app.Region, app.UserPoolId, app.ClientId), nil
+		}
+		if app.TokenCacheSize > 0 {
+			cognito = NewTokenCache(cognito, app.TokenCacheSize, app.TokenCacheTtl, app.TokenCacheNegativeTtl)
+		}
+		return &CognitoProvider{Client: cognito}, nil
+	case config.IdentityProviderOidc:
+		return NewOidcProvider(ctx, app.OidcIssuer, app.OidcClientId, nil)
+	case config.IdentityProviderStatic:
+		// static tokens never expire, keep them away from deployed environments
+		if !app.IsLocalEnv() {
+			return nil, errors.New("static identity provider is only allowed in the local environment")
+		}
+		return LoadStaticProvider(app.StaticTokensFile)
+	case config.IdentityProviderFake:
+		// cognito is the in-memory pool, whose tokens only GetUser can check
+		if !app.IsLocalEnv() {
+			return nil, errors.New("fake cognito identity provider is only allowed in the local environment")
+		}
+		return &CognitoProvider{Client: cognito}, nil
+	}
+	return nil, fmt.Errorf("unknown identity provider: %q", app.IdentityProvider)
+}
@@ -0,0 +1,177 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+)
+
+func newTestOidcIssuer(t *testing.T) (*httptest.Server, *testKeySet) {
+	keys := &testKeySet{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":            server.URL,
+			"jwks_uri":          server.URL + "/keys",
+			"userinfo_endpoint": server.URL + "/userinfo",
+		})
+	})
+	mux.Handle("/keys", keys)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected userinfo not to be consulted")
+		_ = json.NewEncoder(w).Encode(map[string]string{"sub": "user-2", "email": "user2@example.com"})
+	})
+
+	return server, keys
+}
+
+func TestOidcProviderJwt(t *testing.T) {
+	server, keys := newTestOidcIssuer(t)
+	key := keys.add(t, "kid-1")
+
+	p, err := NewOidcProvider(context.Background(), server.URL, "app-1", server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims := map[string]interface{}{
+		"sub":           "user-1",
+		"iss":           server.URL,
+		"aud":           []string{"api", "app-1"},
+		"custom:org_id": "org-1",
+		"exp":           time.Now().Add(time.Hour).Unix(),
+		"amr":           []string{"pwd", "mfa"},
+	}
+	user, _, err := p.Authenticate(context.Background(), signTestToken(t, key, "kid-1", claims))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Id != "user-1" || user.OrgId != "org-1" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+	if len(user.Amr) != 2 || user.Amr[1] != AmrMfa {
+		t.Fatalf("expected authentication methods from the issuer, got %v", user.Amr)
+	}
+
+	claims["aud"] = "other-app"
+	if _, _, err = p.Authenticate(context.Background(), signTestToken(t, key, "kid-1", claims)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected token for another audience to be rejected, got %v", err)
+	}
+}
+
+func TestOidcProviderRejectsOpaqueTokens(t *testing.T) {
+	server, _ := newTestOidcIssuer(t)
+
+	p, err := NewOidcProvider(context.Background(), server.URL, "app-1", server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the issuer would vouch for a token issued to any of its clients
+	if _, _, err = p.Authenticate(context.Background(), "opaque-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected opaque token to be rejected, got %v", err)
+	}
+}
+
+func TestOidcProviderIssuerMismatch(t *testing.T) {
+	server, _ := newTestOidcIssuer(t)
+
+	if _, err := NewOidcProvider(context.Background(), server.URL+"/tenant", "app-1", server.Client()); err == nil {
+		t.Fatal("expected discovery to fail for a different issuer")
+	}
+}
+
+func writeStaticTokens(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStaticProvider(t *testing.T) {
+	path := writeStaticTokens(t, `{"dev-admin": {"id": "user-1", "email": "dev@example.com", "orgId": "org-1"}}`)
+	p, err := LoadStaticProvider(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aw := Middleware{Identity: p, Sessions: &MockSessionStore{}}
+	user, err := callWithAuthorization(aw, "bearer dev-admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Id != "user-1" || user.OrgId != "org-1" || user.SessionId != "static-user-1" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	if _, err = callWithAuthorization(aw, "bearer nope"); err == nil {
+		t.Fatal("expected unknown token to be rejected")
+	}
+
+	if _, err = LoadStaticProvider(writeStaticTokens(t, `{"dev": {"email": "dev@example.com"}}`)); err == nil {
+		t.Fatal("expected token without a user id to be rejected")
+	}
+}
+
+func TestNewIdentityProvider(t *testing.T) {
+	path := writeStaticTokens(t, `{"dev-admin": {"id": "user-1"}}`)
+	app := &config.AppConfig{IdentityProvider: config.IdentityProviderStatic, StaticTokensFile: path}
+	app.Env = "local"
+
+	if p, err := NewIdentityProvider(context.Background(), app, &MockCognito{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, ok := p.(*StaticProvider); !ok {
+		t.Fatalf("expected static provider, got %T", p)
+	}
+
+	app.Env = "dev"
+	if _, err := NewIdentityProvider(context.Background(), app, &MockCognito{}); err == nil {
+		t.Fatal("expected static provider to be refused outside local")
+	}
+
+	app.IdentityProvider = config.IdentityProviderCognito
+	app.TokenCacheSize = 10
+	p, err := NewIdentityProvider(context.Background(), app, &MockCognito{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cognito, ok := p.(*CognitoProvider); !ok {
+		t.Fatalf("expected cognito provider, got %T", p)
+	} else if _, ok = cognito.Client.(*TokenCache); !ok {
+		t.Fatalf("expected cached client, got %T", cognito.Client)
+	}
+
+	app.IdentityProvider = "saml"
+	if _, err = NewIdentityProvider(context.Background(), app, &MockCognito{}); err == nil {
+		t.Fatal("expected unknown provider to fail")
+	}
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	options "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/options/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ScopeSignedIn is carried by tokens from a first party Cognito sign in. It
+// already lets the holder manage the whole account through Cognito, so it
+// satisfies every required scope. Api keys can never hold it.
+const ScopeSignedIn = "aws.cognito.signin.user.admin"
+
+// ScopePolicy holds the required scopes each method declares with the
+// authorization method option
+type ScopePolicy struct {
+	methods map[string][]string
+}
+
+// NewScopePolicy returns a policy that denies every method until loaded
+func NewScopePolicy() *ScopePolicy {
+	return &ScopePolicy{methods: make(map[string][]string)}
+}
+
+// Load reads the authorization option of every method of the services
+// registered on s. Call it after registering services and before serving.
+func (p *ScopePolicy) Load(s *grpc.Server) error {
+	for serviceName, info := range s.GetServiceInfo() {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+		if err != nil {
+			return fmt.Errorf("could not find descriptor for %s: %w", serviceName, err)
+		}
+		service, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return fmt.Errorf("%s is not a service", serviceName)
+		}
+
+		for _, method := range info.Methods {
+			fullMethod := fmt.Sprintf("/%s/%s", serviceName, method.Name)
+			md := service.Methods().ByName(protoreflect.Name(method.Name))
+			if md == nil || !proto.HasExtension(md.Options(), options.E_Authorization) {
+				log.Warnf("method has no authorization option and will be denied: %s", fullMethod)
+				continue
+			}
+			authz := proto.GetExtension(md.Options(), options.E_Authorization).(*options.Authorization)
+			p.methods[fullMethod] = append([]string{}, authz.GetRequiredScopes()...)
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether scopes satisfy the declaration of fullMethod
+func (p *ScopePolicy) Allows(fullMethod string, scopes []string) bool {
+	required, declared := p.methods[fullMethod]
+	if !declared {
+		return false
+	}
+
+	held := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		held[scope] = true
+	}
+	if held[ScopeSignedIn] {
+		return true
+	}
+
+	for _, scope := range required {
+		if !held[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// Interceptor enforces the policy. It runs after the auth middleware, users
+// are checked against their scopes while calls made by a service alone were
+// already limited to the service methods.
+func (p *ScopePolicy) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == "/grpc.health.v1.Health/Check" || info.FullMethod == "/grpc.health.v1.Health/Watch" {
+			return handler(ctx, req)
+		}
+
+		if _, declared := p.methods[info.FullMethod]; !declared {
+			log.WarnfCtx(ctx, "denying method without authorization option: %s", info.FullMethod)
+			return nil, ErrMethodNotAvailable
+		}
+
+		user, err := principal.RequireUser(ctx)
+		if err != nil {
+			if _, isService := principal.ServiceFromContext(ctx); isService {
+				return handler(ctx, req)
+			}
+			return nil, ErrMissingCredentials
+		}
+
+		if !p.Allows(info.FullMethod, user.Scopes) {
+			log.DebugfCtx(ctx, "caller is missing scopes for method: %s - %v", info.FullMethod, user.Scopes)
+			return nil, ErrMissingScope
+		}
+
+		return handler(ctx, req)
+	}
+}
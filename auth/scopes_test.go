@@ -0,0 +1,111 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	options "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/options/v1"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	updateProfileMethod = "/pkg.pbs.profile.v1.ProfileService/UpdateProfile"
+	createApiKeyMethod  = "/pkg.pbs.profile.v1.ProfileService/CreateApiKey"
+)
+
+func loadTestPolicy(t *testing.T) *ScopePolicy {
+	s := grpc.NewServer()
+	profile.RegisterProfileServiceServer(s, &profile.UnimplementedProfileServiceServer{})
+	policy := NewScopePolicy()
+	if err := policy.Load(s); err != nil {
+		t.Fatalf("could not load policy: %v", err)
+	}
+	return policy
+}
+
+func callWithPolicy(policy *ScopePolicy, ctx context.Context, method string) error {
+	_, err := policy.Interceptor()(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: method},
+		func(_ context.Context, _ interface{}) (interface{}, error) {
+			return &struct{}{}, nil
+		})
+	return err
+}
+
+func TestEveryProfileMethodDeclaresAuthorization(t *testing.T) {
+	methods := profile.File_pkg_pbs_profile_v1_profile_proto.Services().ByName("ProfileService").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		if !proto.HasExtension(methods.Get(i).Options(), options.E_Authorization) {
+			t.Errorf("%s has no authorization option", methods.Get(i).Name())
+		}
+	}
+}
+
+func TestScopePolicy(t *testing.T) {
+	policy := loadTestPolicy(t)
+
+	apiKey := model.User{Id: "user-1", ApiKeyId: "key-1", Scopes: []string{ScopeProfileRead}}
+	signedIn := model.User{Id: "user-1", Scopes: []string{ScopeSignedIn}}
+
+	cases := []struct {
+		name   string
+		user   model.User
+		method string
+		code   codes.Code
+	}{
+		{"read key reads", apiKey, readProfileMethod, codes.OK},
+		{"read key cannot write", apiKey, updateProfileMethod, codes.PermissionDenied},
+		{"keys cannot mint keys", model.User{Scopes: []string{ScopeProfileRead, ScopeProfileWrite}}, createApiKeyMethod, codes.PermissionDenied},
+		{"sign in satisfies any scope", signedIn, createApiKeyMethod, codes.OK},
+		{"no scopes", model.User{Id: "user-1"}, readProfileMethod, codes.PermissionDenied},
+		{"undeclared method", signedIn, "/pkg.pbs.profile.v1.ProfileService/Nope", codes.PermissionDenied},
+	}
+
+	for _, c := range cases {
+		ctx := context.WithValue(context.Background(), model.UserCtxKey, c.user)
+		if err := callWithPolicy(policy, ctx, c.method); status.Code(err) != c.code {
+			t.Errorf("%s: expected %v, got %v", c.name, c.code, err)
+		}
+	}
+}
+
+func TestScopePolicyServicesAndHealth(t *testing.T) {
+	policy := loadTestPolicy(t)
+
+	serviceCtx := context.WithValue(context.Background(), model.ServiceCtxKey, model.Service{Name: "order-manager"})
+	if err := callWithPolicy(policy, serviceCtx, lookupProfileMethod); err != nil {
+		t.Fatalf("expected service call to pass, got %v", err)
+	}
+
+	if err := callWithPolicy(policy, context.Background(), lookupProfileMethod); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a caller, got %v", err)
+	}
+
+	if err := callWithPolicy(policy, context.Background(), "/grpc.health.v1.Health/Check"); err != nil {
+		t.Fatalf("expected health checks to pass, got %v", err)
+	}
+
+	if err := callWithPolicy(NewScopePolicy(), serviceCtx, lookupProfileMethod); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected an unloaded policy to deny, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// serviceMethods are the privileged methods a service may call with only its
+// client certificate, every other method still needs a user's credentials
+var serviceMethods = map[string]bool{
+	"/pkg.pbs.profile.v1.ProfileService/LookupProfile": true,
+}
+
+// serviceFromPeer maps the verified client certificate of the connection to
+// a configured service identity. URI SANs such as SPIFFE ids are matched
+// before DNS SANs.
+func (am *Middleware) serviceFromPeer(ctx context.Context) (model.Service, bool) {
+	if len(am.Services) == 0 {
+		return model.Service{}, false
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return model.Service{}, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	// only chains verified against the client CA bundle count
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return model.Service{}, false
+	}
+
+	for _, san := range certificateSans(tlsInfo.State.VerifiedChains[0][0]) {
+		if name, ok := am.Services[san]; ok {
+			return model.Service{Name: name, Subject: san}, true
+		}
+	}
+	return model.Service{}, false
+}
+
+func certificateSans(cert *x509.Certificate) []string {
+	sans := make([]string, 0, len(cert.URIs)+len(cert.DNSNames))
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return append(sans, cert.DNSNames...)
+}
+
+// withService adds the calling service to the context next to any user
+func withService(ctx context.Context, service model.Service) context.Context {
+	fields := log.Fields{"serviceId": service.Name}
+	ctxlogrus.AddFields(ctx, logrus.Fields(fields))
+	ctx = log.AddFields(ctx, fields)
+	return context.WithValue(ctx, model.ServiceCtxKey, service)
+}
@@ -0,0 +1,114 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const lookupProfileMethod = "/pkg.pbs.profile.v1.ProfileService/LookupProfile"
+
+func peerWithCert(ctx context.Context, uri string, verified bool) context.Context {
+	u, _ := url.Parse(uri)
+	cert := &x509.Certificate{URIs: []*url.URL{u}, DNSNames: []string{"orders.internal"}}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func callAsService(aw Middleware, ctx context.Context, method string) (model.Service, model.User, error) {
+	var service model.Service
+	var user model.User
+	_, err := aw.InterceptorNew()(ctx, &struct{}{}, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			service, _ = ctx.Value(model.ServiceCtxKey).(model.Service)
+			user, _ = ctx.Value(model.UserCtxKey).(model.User)
+			return &struct{}{}, nil
+		})
+	return service, user, err
+}
+
+func TestMiddlewareServiceIdentity(t *testing.T) {
+	aw := Middleware{
+		Identity: &CognitoProvider{Client: &MockSubCognito{}},
+		Services: map[string]string{"spiffe://ib/order-manager": "order-manager"},
+	}
+	ctx := peerWithCert(context.Background(), "spiffe://ib/order-manager", true)
+
+	service, user, err := callAsService(aw, ctx, lookupProfileMethod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service.Name != "order-manager" || service.Subject != "spiffe://ib/order-manager" || user.Id != "" {
+		t.Fatalf("unexpected identity: %+v %+v", service, user)
+	}
+
+	// services cannot reach user methods without a user's token
+	if _, _, err = callAsService(aw, ctx, readProfileMethod); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+
+	// with a token the user is added next to the service
+	md := metadata.Pairs("authorization", "bearer "+makeTestToken(t, map[string]interface{}{"sub": "user-1"}))
+	service, user, err = callAsService(aw, metautils.NiceMD(md).ToIncoming(ctx), readProfileMethod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service.Name != "order-manager" || user.Id != "user-1" {
+		t.Fatalf("expected both identities, got %+v %+v", service, user)
+	}
+}
+
+func TestMiddlewareServiceIdentityRejected(t *testing.T) {
+	aw := Middleware{
+		Identity: &CognitoProvider{Client: &MockSubCognito{}},
+		Services: map[string]string{"spiffe://ib/order-manager": "order-manager"},
+	}
+
+	cases := map[string]context.Context{
+		"unverified chain": peerWithCert(context.Background(), "spiffe://ib/order-manager", false),
+		"unknown san":      peerWithCert(context.Background(), "spiffe://ib/other", true),
+		"no peer":          context.Background(),
+	}
+	for name, ctx := range cases {
+		if _, _, err := callAsService(aw, ctx, lookupProfileMethod); err == nil {
+			t.Errorf("%s: expected call without credentials to fail", name)
+		}
+	}
+
+	// DNS SANs are matched too
+	aw.Services = map[string]string{"orders.internal": "order-manager"}
+	service, _, err := callAsService(aw, peerWithCert(context.Background(), "spiffe://ib/other", true), lookupProfileMethod)
+	if err != nil || service.Name != "order-manager" {
+		t.Fatalf("expected dns san to match, got %+v %v", service, err)
+	}
+}
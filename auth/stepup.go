@@ -0,0 +1,81 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// AmrMfa is the authentication method reference of a multi factor sign in
+const AmrMfa = "mfa"
+
+// StepUpPolicy guards sensitive fields, changing one needs a sign in within
+// MaxAge or one backed by multiple factors
+type StepUpPolicy struct {
+	fields map[string]bool
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewStepUpPolicy returns a policy for the named fields. Without fields it
+// never asks for a step up.
+func NewStepUpPolicy(fields []string, maxAge time.Duration) *StepUpPolicy {
+	p := &StepUpPolicy{
+		fields: make(map[string]bool),
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+	for _, field := range fields {
+		p.fields[field] = true
+	}
+	return p
+}
+
+// Check returns ErrStepUpRequired when changed names a sensitive field and
+// the user's sign in is neither recent nor multi factor. The error lists the
+// sensitive fields and the freshness window so the client can send the user
+// through sign in again.
+func (p *StepUpPolicy) Check(user model.User, changed []string) error {
+	var sensitive []string
+	for _, field := range changed {
+		if p.fields[field] {
+			sensitive = append(sensitive, field)
+		}
+	}
+	if len(sensitive) == 0 || p.satisfiedBy(user) {
+		return nil
+	}
+
+	return ErrStepUpRequired.
+		With("fields", strings.Join(sensitive, ",")).
+		With("maxAgeSeconds", strconv.FormatInt(int64(p.maxAge/time.Second), 10))
+}
+
+// satisfiedBy reports whether the user's sign in is strong enough. Api keys
+// carry no sign in, so they can never change sensitive fields.
+func (p *StepUpPolicy) satisfiedBy(user model.User) bool {
+	for _, amr := range user.Amr {
+		if amr == AmrMfa {
+			return true
+		}
+	}
+	return !user.AuthTime.IsZero() && p.now().Sub(user.AuthTime) <= p.maxAge
+}
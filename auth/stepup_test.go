@@ -0,0 +1,78 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func TestStepUpPolicy(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	policy := NewStepUpPolicy([]string{"email", "legal_name", "address"}, 15*time.Minute)
+	policy.now = func() time.Time { return now }
+
+	cases := []struct {
+		name    string
+		user    model.User
+		changed []string
+		stepUp  bool
+	}{
+		{"recent sign in", model.User{AuthTime: now.Add(-10 * time.Minute)}, []string{"email"}, false},
+		{"stale sign in", model.User{AuthTime: now.Add(-20 * time.Minute)}, []string{"name", "email"}, true},
+		{"stale multi factor sign in", model.User{AuthTime: now.Add(-20 * time.Minute), Amr: []string{"pwd", AmrMfa}}, []string{"address"}, false},
+		{"insensitive fields", model.User{AuthTime: now.Add(-20 * time.Minute)}, []string{"name", "user_name"}, false},
+		{"api key", model.User{AuthMethod: model.AuthMethodApiKey}, []string{"legal_name"}, true},
+	}
+
+	for _, c := range cases {
+		err := policy.Check(c.user, c.changed)
+		if c.stepUp != errors.Is(err, ErrStepUpRequired) {
+			t.Errorf("%s: expected step up %v, got %v", c.name, c.stepUp, err)
+		}
+	}
+}
+
+func TestStepUpErrorDescribesRequirement(t *testing.T) {
+	policy := NewStepUpPolicy([]string{"email", "address"}, 5*time.Minute)
+
+	s := errs.ToStatus(policy.Check(model.User{}, []string{"address", "name", "email"}))
+	if s.Code() != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", s.Code())
+	}
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.Reason != "STEP_UP_REQUIRED" || info.Metadata["fields"] != "address,email" || info.Metadata["maxAgeSeconds"] != "300" {
+				t.Fatalf("unexpected error info: %v", info)
+			}
+			return
+		}
+	}
+	t.Fatal("expected ErrorInfo details")
+}
+
+func TestStepUpPolicyWithoutFields(t *testing.T) {
+	if err := NewStepUpPolicy(nil, time.Minute).Check(model.User{}, []string{"email"}); err != nil {
+		t.Fatalf("expected no step up without sensitive fields, got %v", err)
+	}
+}
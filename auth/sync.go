@@ -0,0 +1,203 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/aws/smithy-go"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+const (
+	EmailAttribute = "email"
+	NameAttribute  = "name"
+
+	defaultSyncAttempts = 3
+	defaultSyncBackoff  = 200 * time.Millisecond
+)
+
+// AttributeSyncStore keeps attribute updates that could not be applied so
+// they can be retried
+type AttributeSyncStore interface {
+	RecordAttributeSync(ctx context.Context, sync model.AttributeSync) (model.AttributeSync, error)
+	ListAttributeSyncs(ctx context.Context, provider string) ([]model.AttributeSync, error)
+	DeleteAttributeSync(ctx context.Context, provider, userId string) error
+}
+
+// AttributeSynchronizer copies profile changes to the Cognito user pool so
+// the attributes in issued tokens do not drift from the profile
+type AttributeSynchronizer struct {
+	Cognito *CognitoClient
+	Store   AttributeSyncStore
+	// Attempts is how many times an update is tried before it is left to
+	// the retry loop
+	Attempts int
+	// Backoff is the wait before the second attempt, doubled after that
+	Backoff time.Duration
+}
+
+func NewAttributeSynchronizer(cognito *CognitoClient, store AttributeSyncStore) *AttributeSynchronizer {
+	return &AttributeSynchronizer{
+		Cognito:  cognito,
+		Store:    store,
+		Attempts: defaultSyncAttempts,
+		Backoff:  defaultSyncBackoff,
+	}
+}
+
+// SyncAttributes writes the attributes to the user pool. An update that
+// still fails after retrying is recorded and tried again by RetryPending, an
+// update Cognito rejects outright is recorded as failed so it can be looked
+// into and replayed.
+func (s *AttributeSynchronizer) SyncAttributes(ctx context.Context, userId string, attributes map[string]string) error {
+	err := s.update(ctx, userId, attributes)
+	if err == nil {
+		// a pending update would otherwise overwrite this one when retried
+		if err := s.Store.DeleteAttributeSync(ctx, model.AttributeSyncCognito, userId); err != nil {
+			log.WarnfCtx(ctx, "could not clear pending attribute sync: %v", err)
+		}
+		return nil
+	}
+
+	if _, recordErr := s.Store.RecordAttributeSync(ctx, failedSync(model.AttributeSync{
+		Provider:   model.AttributeSyncCognito,
+		UserId:     userId,
+		Attributes: attributes,
+		Attempts:   s.attempts(),
+	}, err)); recordErr != nil {
+		return fmt.Errorf("could not record failed attribute sync: %v: %w", recordErr, err)
+	}
+	return err
+}
+
+// RetryPending tries every pending update once more, dropping the ones that
+// now succeed and marking the ones Cognito rejects as failed. Failed updates
+// are left alone until they are set back to pending.
+func (s *AttributeSynchronizer) RetryPending(ctx context.Context) error {
+	pending, err := s.Store.ListAttributeSyncs(ctx, model.AttributeSyncCognito)
+	if err != nil {
+		return err
+	}
+
+	for _, sync := range pending {
+		if sync.IsFailed() {
+			continue
+		}
+		err := s.update(ctx, sync.UserId, sync.Attributes)
+		if err != nil {
+			sync.Attempts += s.attempts()
+			sync = failedSync(sync, err)
+			if sync.IsFailed() {
+				log.WarnfCtx(ctx, "attribute sync for %s failed: %v", sync.UserId, err)
+			}
+			if _, err := s.Store.RecordAttributeSync(ctx, sync); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.Store.DeleteAttributeSync(ctx, sync.Provider, sync.UserId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run retries pending updates every interval until ctx is done
+func (s *AttributeSynchronizer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RetryPending(ctx); err != nil {
+				log.Warnf("could not retry attribute syncs: %v", err)
+			}
+		}
+	}
+}
+
+func (s *AttributeSynchronizer) update(ctx context.Context, userId string, attributes map[string]string) error {
+	backoff := s.Backoff
+	var err error
+	for attempt := 0; attempt < s.attempts(); attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+
+		if err = s.Cognito.UpdateUserAttributes(ctx, userId, attributes); err == nil || !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *AttributeSynchronizer) attempts() int {
+	if s.Attempts < 1 {
+		return 1
+	}
+	return s.Attempts
+}
+
+// failedSync fills in the outcome of a failed update, which stays pending
+// unless Cognito rejected it outright
+func failedSync(sync model.AttributeSync, err error) model.AttributeSync {
+	sync.Status = model.AttributeSyncPending
+	if !isRetryable(err) {
+		sync.Status = model.AttributeSyncFailed
+	}
+	sync.Reason = "Unknown"
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		sync.Reason = apiErr.ErrorCode()
+	}
+	sync.LastError = err.Error()
+	sync.FailedAt = time.Now()
+	return sync
+}
+
+// isRetryable reports whether a failed update might succeed if sent again,
+// requests Cognito refuses on their content never will
+func isRetryable(err error) bool {
+	var (
+		userNotFound  *types.UserNotFoundException
+		invalidParam  *types.InvalidParameterException
+		aliasExists   *types.AliasExistsException
+		notAuthorized *types.NotAuthorizedException
+	)
+	switch {
+	case errors.As(err, &userNotFound), errors.As(err, &invalidParam),
+		errors.As(err, &aliasExists), errors.As(err, &notAuthorized):
+		return false
+	}
+	return true
+}
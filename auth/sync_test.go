@@ -0,0 +1,283 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// fakeCognitoAdmin is an in-memory user pool that fails the next failures
+// calls with failWith
+type fakeCognitoAdmin struct {
+	mu       sync.Mutex
+	users    map[string]map[string]string
+	calls    int
+	failures int
+	failWith error
+}
+
+func newFakeCognitoAdmin(userIds ...string) *fakeCognitoAdmin {
+	f := &fakeCognitoAdmin{users: map[string]map[string]string{}}
+	for _, id := range userIds {
+		f.users[id] = map[string]string{}
+	}
+	return f
+}
+
+func (f *fakeCognitoAdmin) AdminUpdateUserAttributes(ctx context.Context, params *cip.AdminUpdateUserAttributesInput, optFns ...func(*cip.Options)) (*cip.AdminUpdateUserAttributesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return nil, f.failWith
+	}
+	attributes, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, &types.UserNotFoundException{Message: aws.String("User does not exist.")}
+	}
+	for _, a := range params.UserAttributes {
+		attributes[aws.ToString(a.Name)] = aws.ToString(a.Value)
+	}
+	return &cip.AdminUpdateUserAttributesOutput{}, nil
+}
+
+func (f *fakeCognitoAdmin) AdminGetUser(ctx context.Context, params *cip.AdminGetUserInput, optFns ...func(*cip.Options)) (*cip.AdminGetUserOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	attributes, ok := f.users[aws.ToString(params.Username)]
+	if !ok {
+		return nil, &types.UserNotFoundException{Message: aws.String("User does not exist.")}
+	}
+	output := &cip.AdminGetUserOutput{Username: params.Username}
+	for name, value := range attributes {
+		output.UserAttributes = append(output.UserAttributes, types.AttributeType{Name: aws.String(name), Value: aws.String(value)})
+	}
+	return output, nil
+}
+
+func (f *fakeCognitoAdmin) AdminUserGlobalSignOut(ctx context.Context, params *cip.AdminUserGlobalSignOutInput, optFns ...func(*cip.Options)) (*cip.AdminUserGlobalSignOutOutput, error) {
+	return &cip.AdminUserGlobalSignOutOutput{}, nil
+}
+
+func (f *fakeCognitoAdmin) attribute(userId, name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.users[userId][name]
+}
+
+type memoryAttributeSyncStore struct {
+	syncs map[string]model.AttributeSync
+}
+
+func (s *memoryAttributeSyncStore) RecordAttributeSync(ctx context.Context, sync model.AttributeSync) (model.AttributeSync, error) {
+	s.syncs[sync.UserId] = sync
+	return sync, nil
+}
+
+func (s *memoryAttributeSyncStore) ListAttributeSyncs(ctx context.Context, provider string) ([]model.AttributeSync, error) {
+	syncs := []model.AttributeSync{}
+	for _, sync := range s.syncs {
+		if sync.Provider == provider {
+			syncs = append(syncs, sync)
+		}
+	}
+	return syncs, nil
+}
+
+func (s *memoryAttributeSyncStore) DeleteAttributeSync(ctx context.Context, provider, userId string) error {
+	delete(s.syncs, userId)
+	return nil
+}
+
+func newTestSynchronizer(admin AdminClient) (*AttributeSynchronizer, *memoryAttributeSyncStore) {
+	store := &memoryAttributeSyncStore{syncs: map[string]model.AttributeSync{}}
+	s := NewAttributeSynchronizer(&CognitoClient{UserPoolId: "pool", Admin: admin}, store)
+	s.Backoff = time.Millisecond
+	return s, store
+}
+
+var syncedAttributes = map[string]string{
+	EmailAttribute: "bob@example.com",
+	NameAttribute:  "Bob Ross",
+}
+
+func TestSyncAttributes(t *testing.T) {
+	admin := newFakeCognitoAdmin("123")
+	s, store := newTestSynchronizer(admin)
+
+	if err := s.SyncAttributes(context.Background(), "123", syncedAttributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := admin.attribute("123", EmailAttribute); got != "bob@example.com" {
+		t.Fatalf("expected email to be synced, got %q", got)
+	}
+	if got := admin.attribute("123", NameAttribute); got != "Bob Ross" {
+		t.Fatalf("expected name to be synced, got %q", got)
+	}
+	if len(store.syncs) != 0 {
+		t.Fatalf("expected nothing pending, got %v", store.syncs)
+	}
+}
+
+func TestSyncAttributesRetriesTransientErrors(t *testing.T) {
+	admin := newFakeCognitoAdmin("123")
+	admin.failures = 2
+	admin.failWith = &types.TooManyRequestsException{Message: aws.String("slow down")}
+	s, store := newTestSynchronizer(admin)
+
+	if err := s.SyncAttributes(context.Background(), "123", syncedAttributes); err != nil {
+		t.Fatal(err)
+	}
+
+	if admin.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", admin.calls)
+	}
+	if len(store.syncs) != 0 {
+		t.Fatalf("expected nothing pending, got %v", store.syncs)
+	}
+}
+
+func TestSyncAttributesRecordsFailure(t *testing.T) {
+	admin := newFakeCognitoAdmin("123")
+	admin.failures = 3
+	admin.failWith = &types.InternalErrorException{Message: aws.String("unavailable")}
+	s, store := newTestSynchronizer(admin)
+
+	err := s.SyncAttributes(context.Background(), "123", syncedAttributes)
+	var internal *types.InternalErrorException
+	if !errors.As(err, &internal) {
+		t.Fatalf("expected the cognito error, got %v", err)
+	}
+
+	pending, ok := store.syncs["123"]
+	if !ok {
+		t.Fatal("expected the failed sync to be recorded")
+	}
+	if pending.Provider != model.AttributeSyncCognito || pending.Attempts != 3 || pending.Attributes[EmailAttribute] != "bob@example.com" {
+		t.Fatalf("unexpected pending sync %+v", pending)
+	}
+
+	if err := s.RetryPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.syncs) != 0 {
+		t.Fatalf("expected the retried sync to be cleared, got %v", store.syncs)
+	}
+	if got := admin.attribute("123", EmailAttribute); got != "bob@example.com" {
+		t.Fatalf("expected email to be synced on retry, got %q", got)
+	}
+}
+
+func TestSyncAttributesPermanentFailure(t *testing.T) {
+	admin := newFakeCognitoAdmin()
+	s, store := newTestSynchronizer(admin)
+
+	err := s.SyncAttributes(context.Background(), "missing", syncedAttributes)
+	var notFound *types.UserNotFoundException
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+	if admin.calls != 1 {
+		t.Fatalf("expected no retries, got %d calls", admin.calls)
+	}
+
+	failed, ok := store.syncs["missing"]
+	if !ok {
+		t.Fatal("expected the rejected sync to be recorded")
+	}
+	if !failed.IsFailed() || failed.Reason != "UserNotFoundException" || failed.Attributes[EmailAttribute] != "bob@example.com" {
+		t.Fatalf("unexpected failed sync %+v", failed)
+	}
+
+	if err := s.RetryPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if admin.calls != 1 {
+		t.Fatalf("expected a failed sync not to be retried, got %d calls", admin.calls)
+	}
+	if _, ok := store.syncs["missing"]; !ok {
+		t.Fatal("expected the failed sync to be kept for a replay")
+	}
+
+	admin.users["missing"] = map[string]string{}
+	failed.Status = model.AttributeSyncPending
+	store.syncs["missing"] = failed
+	if err := s.RetryPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := admin.attribute("missing", EmailAttribute); got != "bob@example.com" {
+		t.Fatalf("expected the replayed sync to apply, got %q", got)
+	}
+	if len(store.syncs) != 0 {
+		t.Fatalf("expected the replayed sync to be cleared, got %v", store.syncs)
+	}
+}
+
+func TestRetryPendingKeepsFailures(t *testing.T) {
+	admin := newFakeCognitoAdmin("123")
+	admin.failures = 100
+	admin.failWith = &types.TooManyRequestsException{Message: aws.String("slow down")}
+	s, store := newTestSynchronizer(admin)
+	store.syncs["123"] = model.AttributeSync{
+		Provider:   model.AttributeSyncCognito,
+		UserId:     "123",
+		Attributes: syncedAttributes,
+		Attempts:   3,
+	}
+
+	if err := s.RetryPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := store.syncs["123"].Attempts; got != 6 {
+		t.Fatalf("expected 6 attempts, got %d", got)
+	}
+}
+
+func TestSyncAttributesClearsStalePending(t *testing.T) {
+	admin := newFakeCognitoAdmin("123")
+	s, store := newTestSynchronizer(admin)
+	store.syncs["123"] = model.AttributeSync{
+		Provider:   model.AttributeSyncCognito,
+		UserId:     "123",
+		Attributes: map[string]string{EmailAttribute: "old@example.com", NameAttribute: "Old"},
+	}
+
+	if err := s.SyncAttributes(context.Background(), "123", syncedAttributes); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RetryPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := admin.attribute("123", EmailAttribute); got != "bob@example.com" {
+		t.Fatalf("expected the stale update to be dropped, got %q", got)
+	}
+}
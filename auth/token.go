@@ -0,0 +1,133 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// TokenClaims are the Cognito access token claims the service relies on
+type TokenClaims struct {
+	Subject   string   `json:"sub"`
+	TokenId   string   `json:"jti"`
+	OriginJti string   `json:"origin_jti"`
+	ClientId  string   `json:"client_id"`
+	Username  string   `json:"username"`
+	TokenUse  string   `json:"token_use"`
+	Scope     string   `json:"scope"`
+	Groups    []string `json:"cognito:groups"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	Azp       string   `json:"azp"`
+	ExpiresAt int64    `json:"exp"`
+	IssuedAt  int64    `json:"iat"`
+	AuthTime  int64    `json:"auth_time"`
+	// Amr lists how the user authenticated, e.g. mfa. It is only trusted
+	// from OIDC issuers: Cognito access tokens do not carry it, and one added
+	// by a pre token generation trigger would not say how the user signed in.
+	Amr []string `json:"amr"`
+	// Email and OrgId are only present when a pre token generation trigger
+	// adds them to the access token
+	Email string `json:"email"`
+	OrgId string `json:"custom:org_id"`
+}
+
+// SessionId identifies the authentication a token was issued from. Cognito
+// keeps origin_jti stable across refreshes, so every access token minted from
+// the same sign in maps to the same session.
+func (c TokenClaims) SessionId() string {
+	if c.OriginJti != "" {
+		return c.OriginJti
+	}
+	return c.TokenId
+}
+
+// applyTo copies the identity details carried by the claims onto the user
+func (c TokenClaims) applyTo(user *model.User) {
+	if c.Username != "" {
+		user.Username = c.Username
+	}
+	user.Groups = c.Groups
+	if c.ExpiresAt != 0 {
+		user.ExpiresAt = time.Unix(c.ExpiresAt, 0).UTC()
+	}
+	if c.AuthTime != 0 {
+		user.AuthTime = time.Unix(c.AuthTime, 0).UTC()
+	}
+}
+
+// issuedTo reports whether the token was minted for clientId. Cognito access
+// tokens name it in client_id, other OIDC issuers in aud or azp.
+func (c TokenClaims) issuedTo(clientId string) bool {
+	if clientId == "" {
+		return false
+	}
+	if c.ClientId == clientId || c.Azp == clientId {
+		return true
+	}
+	for _, aud := range c.Audience {
+		if aud == clientId {
+			return true
+		}
+	}
+	return false
+}
+
+// audience is the aud claim, which may be a single string or a list
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// parseUnverifiedClaims decodes the payload of a JWT without checking its
+// signature. Only use it on tokens that have already been validated.
+func parseUnverifiedClaims(token string) (TokenClaims, error) {
+	var claims TokenClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, errors.New("token is not a jwt")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, errors.New("could not decode token payload")
+	}
+
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return claims, errors.New("could not parse token claims")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,69 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func makeTestToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestParseUnverifiedClaims(t *testing.T) {
+	token := makeTestToken(t, map[string]interface{}{
+		"sub":        "user-1",
+		"jti":        "token-2",
+		"origin_jti": "origin-1",
+		"exp":        1700000000,
+	})
+
+	claims, err := parseUnverifiedClaims(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if claims.Subject != "user-1" || claims.ExpiresAt != 1700000000 {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if claims.SessionId() != "origin-1" {
+		t.Fatal("expected session id to come from origin_jti")
+	}
+}
+
+func TestSessionIdFallsBackToJti(t *testing.T) {
+	claims := TokenClaims{TokenId: "token-2"}
+	if claims.SessionId() != "token-2" {
+		t.Fatal("expected session id to fall back to jti")
+	}
+}
+
+func TestParseUnverifiedClaimsInvalid(t *testing.T) {
+	for _, token := range []string{"goodToken", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("nope")) + ".c"} {
+		if _, err := parseUnverifiedClaims(token); err == nil {
+			t.Fatalf("expected error for %q", token)
+		}
+	}
+}
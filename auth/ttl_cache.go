@@ -0,0 +1,97 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultTtlCacheSize bounds the per user and per session caches of the
+// middleware
+const defaultTtlCacheSize = 10000
+
+type ttlEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// ttlCache remembers values for a fixed time. Expired entries are swept once
+// the cache outgrows its size, and if all of them are still live it starts
+// over. A nil cache remembers nothing, so callers need no special case when
+// caching is turned off.
+type ttlCache struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+}
+
+// newTtlCache returns nil, a cache that remembers nothing, when ttl is not
+// positive
+func newTtlCache(ttl time.Duration, size int) *ttlCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &ttlCache{
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+		entries: make(map[string]ttlEntry),
+	}
+}
+
+func (c *ttlCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache) set(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= c.size {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.size {
+			c.entries = make(map[string]ttlEntry)
+		}
+	}
+	c.entries[key] = ttlEntry{value: value, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,154 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// clockSkew is how far past exp a token is still accepted
+const clockSkew = 30 * time.Second
+
+// JwtVerifier validates access tokens locally against the issuer's
+// published signing keys instead of calling it on every request
+type JwtVerifier struct {
+	Issuer   string
+	ClientId string
+	// TokenUse, when set, must match the token_use claim
+	TokenUse string
+	Keys     *JwksCache
+	now      func() time.Time
+}
+
+// CognitoIssuer is the iss claim of tokens issued by the user pool
+func CognitoIssuer(region, userPoolId string) string {
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", region, userPoolId)
+}
+
+func NewCognitoVerifier(region, userPoolId, clientId string) *JwtVerifier {
+	issuer := CognitoIssuer(region, userPoolId)
+	return &JwtVerifier{
+		Issuer:   issuer,
+		ClientId: clientId,
+		TokenUse: "access",
+		Keys:     NewJwksCache(issuer+"/.well-known/jwks.json", nil),
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v *JwtVerifier) Verify(ctx context.Context, token string) (TokenClaims, error) {
+	var claims TokenClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("%w: not a jwt", ErrInvalidToken)
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, fmt.Errorf("%w: could not decode header", ErrInvalidToken)
+	}
+	var header jwtHeader
+	if err = json.Unmarshal(rawHeader, &header); err != nil {
+		return claims, fmt.Errorf("%w: could not parse header", ErrInvalidToken)
+	}
+	// the algorithm is pinned, never taken from the token
+	if header.Alg != "RS256" {
+		return claims, fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, header.Alg)
+	}
+
+	key, err := v.Keys.Key(ctx, header.Kid)
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return claims, fmt.Errorf("%w: unknown kid %q", ErrInvalidToken, header.Kid)
+		}
+		return claims, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, fmt.Errorf("%w: could not decode signature", ErrInvalidToken)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return claims, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	if claims, err = parseUnverifiedClaims(token); err != nil {
+		return claims, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Issuer != v.Issuer {
+		return claims, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	// Cognito id tokens are signed by the same keys and must not be used as bearer tokens
+	if v.TokenUse != "" && claims.TokenUse != v.TokenUse {
+		return claims, fmt.Errorf("%w: unexpected token_use %q", ErrInvalidToken, claims.TokenUse)
+	}
+	if !claims.issuedTo(v.ClientId) {
+		return claims, fmt.Errorf("%w: not issued to client %q", ErrInvalidToken, v.ClientId)
+	}
+	if claims.Subject == "" {
+		return claims, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	if now().Add(-clockSkew).After(time.Unix(claims.ExpiresAt, 0)) {
+		return claims, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (v *JwtVerifier) Authenticate(ctx context.Context, token string) (model.User, TokenClaims, error) {
+	claims, err := v.Verify(ctx, token)
+	if err != nil {
+		return model.User{}, claims, err
+	}
+	return userFromClaims(claims, ""), claims, nil
+}
+
+// userFromClaims builds the request user from verified token claims
+func userFromClaims(claims TokenClaims, sessionId string) model.User {
+	user := model.User{
+		Id:        claims.Subject,
+		Email:     claims.Email,
+		OrgId:     claims.OrgId,
+		SessionId: sessionId,
+		Scopes:    strings.Fields(claims.Scope),
+	}
+	claims.applyTo(&user)
+	return user
+}
@@ -0,0 +1,294 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testIssuer   = "https://cognito-idp.us-east-2.amazonaws.com/us-east-2_test"
+	testClientId = "client-1"
+)
+
+type testKeySet struct {
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func (s *testKeySet) add(t *testing.T, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = key
+	return key
+}
+
+func (s *testKeySet) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for kid, key := range s.keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+func newTestVerifier(t *testing.T) (*JwtVerifier, *testKeySet) {
+	keys := &testKeySet{keys: map[string]*rsa.PrivateKey{}}
+	server := httptest.NewServer(keys)
+	t.Cleanup(server.Close)
+
+	return &JwtVerifier{
+		Issuer:   testIssuer,
+		ClientId: testClientId,
+		TokenUse: "access",
+		Keys:     NewJwksCache(server.URL, server.Client()),
+	}, keys
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("could not marshal claims: %v", err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("could not sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":           "user-1",
+		"jti":           "jti-1",
+		"origin_jti":    "origin-1",
+		"iss":           testIssuer,
+		"client_id":     testClientId,
+		"token_use":     "access",
+		"scope":         "aws.cognito.signin.user.admin profile:read",
+		"email":         "user@example.com",
+		"custom:org_id": "org-1",
+		"exp":           time.Now().Add(time.Hour).Unix(),
+		"iat":           time.Now().Unix(),
+	}
+}
+
+func TestVerifyValidToken(t *testing.T) {
+	v, keys := newTestVerifier(t)
+	key := keys.add(t, "kid-1")
+
+	claims, err := v.Verify(context.Background(), signTestToken(t, key, "kid-1", validClaims()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.SessionId() != "origin-1" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	user := userFromClaims(claims, "origin-1")
+	if user.Id != "user-1" || user.Email != "user@example.com" || user.OrgId != "org-1" || len(user.Scopes) != 2 {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	v, keys := newTestVerifier(t)
+	key := keys.add(t, "kid-1")
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	with := func(k string, val interface{}) map[string]interface{} {
+		c := validClaims()
+		c[k] = val
+		return c
+	}
+
+	cases := map[string]string{
+		"bad signature":  signTestToken(t, other, "kid-1", validClaims()),
+		"wrong issuer":   signTestToken(t, key, "kid-1", with("iss", "https://evil.example.com")),
+		"wrong client":   signTestToken(t, key, "kid-1", with("client_id", "client-2")),
+		"id token":       signTestToken(t, key, "kid-1", with("token_use", "id")),
+		"expired":        signTestToken(t, key, "kid-1", with("exp", time.Now().Add(-time.Hour).Unix())),
+		"missing sub":    signTestToken(t, key, "kid-1", with("sub", "")),
+		"unsigned":       makeTestToken(t, validClaims()),
+		"not a jwt":      "goodToken",
+		"unknown kid":    signTestToken(t, key, "kid-2", validClaims()),
+		"tampered claim": tamper(signTestToken(t, key, "kid-1", validClaims())),
+	}
+
+	for name, token := range cases {
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected invalid token, got %v", name, err)
+		}
+	}
+}
+
+// tamper swaps the payload of a signed token while keeping its signature
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	claims := validClaims()
+	claims["sub"] = "user-2"
+	payload, _ := json.Marshal(claims)
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+}
+
+func TestVerifyRefreshesOnUnknownKid(t *testing.T) {
+	v, keys := newTestVerifier(t)
+	key := keys.add(t, "kid-1")
+
+	if _, err := v.Verify(context.Background(), signTestToken(t, key, "kid-1", validClaims())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the pool rotates its keys after the first fetch
+	rotated := keys.add(t, "kid-2")
+	v.Keys.attemptedAt = time.Now().Add(-2 * minJwksRefresh)
+
+	if _, err := v.Verify(context.Background(), signTestToken(t, rotated, "kid-2", validClaims())); err != nil {
+		t.Fatalf("expected rotated key to be fetched: %v", err)
+	}
+	if keys.fetches != 2 {
+		t.Fatalf("expected 2 jwks fetches, got %d", keys.fetches)
+	}
+
+	// a made up kid right after a refresh does not hit the endpoint again
+	if _, err := v.Verify(context.Background(), signTestToken(t, rotated, "kid-3", validClaims())); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+	if keys.fetches != 2 {
+		t.Fatalf("expected jwks refresh to be rate limited, got %d fetches", keys.fetches)
+	}
+}
+
+func TestJwksBacksOffAfterFailure(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	cache := NewJwksCache(server.URL, server.Client())
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Key(context.Background(), "kid-1"); err == nil || errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("expected the fetch error, got %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Fatalf("expected a failed fetch to hold off retries, got %d fetches", n)
+	}
+}
+
+func TestJwksLookupsDoNotWaitForRefresh(t *testing.T) {
+	keys := &testKeySet{keys: map[string]*rsa.PrivateKey{}}
+	keys.add(t, "kid-1")
+	started, release := make(chan struct{}), make(chan struct{})
+	var slow int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&slow) == 1 {
+			close(started)
+			<-release
+		}
+		keys.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	cache := NewJwksCache(server.URL, server.Client())
+
+	if _, err := cache.Key(context.Background(), "kid-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a refresh for an unknown kid hangs on the endpoint
+	atomic.StoreInt32(&slow, 1)
+	cache.mu.Lock()
+	cache.attemptedAt = time.Now().Add(-2 * minJwksRefresh)
+	cache.mu.Unlock()
+	done := make(chan error, 1)
+	go func() {
+		_, err := cache.Key(context.Background(), "kid-2")
+		done <- err
+	}()
+	<-started
+
+	if _, err := cache.Key(context.Background(), "kid-1"); err != nil {
+		t.Fatalf("expected the known key while refreshing, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected unknown key, got %v", err)
+	}
+}
+
+func TestMiddlewareJwtProvider(t *testing.T) {
+	v, keys := newTestVerifier(t)
+	key := keys.add(t, "kid-1")
+	sessions := &MockSessionStore{}
+	aw := Middleware{Identity: v, Sessions: sessions}
+
+	authed, err := callWithAuthorization(aw, "bearer "+signTestToken(t, key, "kid-1", validClaims()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authed.Id != "user-1" || authed.OrgId != "org-1" || authed.SessionId != "origin-1" {
+		t.Fatalf("unexpected user: %+v", authed)
+	}
+	if len(sessions.touched) != 1 || sessions.touched[0].TokenId != "jti-1" {
+		t.Fatalf("expected session to be recorded, got %+v", sessions.touched)
+	}
+
+	_, err = callWithAuthorization(aw, "bearer "+makeTestToken(t, validClaims()))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated for unsigned token, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/hooks"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+)
+
+// main runs the Cognito PostConfirmation trigger as a Lambda function, using
+// the same configuration as the server
+func main() {
+	var app config.AppConfig
+
+	config.Setup(&app)
+	log.Init(app)
+
+	cfg, err := awsConfig.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("cannot read aws config: %v", err)
+	}
+
+	repo := dba.NewRepo(&app, cfg)
+	profiles, err := dba.NewProfileStore(context.Background(), &app, repo)
+	if err != nil {
+		log.Fatalf("cannot setup profile store: %v", err)
+	}
+
+	hook := &hooks.PostConfirmation{Repo: repo, Profiles: profiles}
+	lambda.Start(hook.Handle)
+}
@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
@@ -28,9 +29,11 @@ import (
 
 	"github.com/coinbase-samples/ib-usermgr-go/auth"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
 	"github.com/coinbase-samples/ib-usermgr-go/handlers"
 	"github.com/coinbase-samples/ib-usermgr-go/log"
 	v1 "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/ratelimit"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -43,7 +46,7 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-func gRPCListen(app config.AppConfig, aw auth.Middleware) {
+func gRPCListen(app config.AppConfig, aw auth.Middleware, dir handlers.Directory, attributes handlers.AttributeSyncer, localCognito http.Handler) {
 
 	// if local expose both grpc and http endpoints
 	activePort := app.Port
@@ -57,13 +60,38 @@ func gRPCListen(app config.AppConfig, aw auth.Middleware) {
 		log.Fatalf("Failed to listen for gRPC: %v", err)
 	}
 
-	s := grpc.NewServer(setupGrpcOptions(app, aw)...)
+	limiter, err := ratelimit.NewLimiter(app.GetRateLimits(), app.RateLimitDefault)
+	if err != nil {
+		log.Fatalf("Failed to parse rate limits: %v", err)
+	}
+	addressLimiter, err := ratelimit.NewLimiter(nil, app.RateLimitAddress)
+	if err != nil {
+		log.Fatalf("Failed to parse address rate limit: %v", err)
+	}
+	proxies, err := auth.ParseTrustedProxies(app.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
+	policy := auth.NewScopePolicy()
+	s := grpc.NewServer(setupGrpcOptions(app, aw, policy, proxies, addressLimiter, limiter)...)
 
 	//register grpc handlers
-	v1.RegisterProfileServiceServer(s, &handlers.ProfileServer{})
+	v1.RegisterProfileServiceServer(s, &handlers.ProfileServer{
+		RequiredConsents: app.GetRequiredConsents(),
+		Directory:        dir,
+		Impersonators:    app.GetImpersonators(),
+		Attributes:       attributes,
+		StepUp:           auth.NewStepUpPolicy(app.GetStepUpFields(), app.StepUpMaxAge),
+		RevokedTokenTtl:  app.RevocationTokenTtl,
+	})
 	registerHealth(s)
 	reflection.Register(s)
 
+	if err := policy.Load(s); err != nil {
+		log.Fatalf("Failed to load authorization policy: %v", err)
+	}
+
 	log.Infof("gRPC Server starting on port %s", activePort)
 	go func() {
 		if err := s.Serve(lis); err != nil {
@@ -74,12 +102,22 @@ func gRPCListen(app config.AppConfig, aw auth.Middleware) {
 	//if local, start http as an interface
 	var gwServer *http.Server
 	if app.IsLocalEnv() {
-		gwServer, err = setupHttp(app, s)
+		gwServer, err = setupHttp(app, s, localCognito)
 		if err != nil {
 			log.Errorf("issues setting up http server: %v", err)
 		}
 	}
 
+	var hookServer *http.Server
+	if !app.IsLocalEnv() && app.HookSecret != "" {
+		hookServer = setupHookHttp(app)
+	}
+
+	var metricsServer *http.Server
+	if app.MetricsPort != "" {
+		metricsServer = setupMetricsHttp(app)
+	}
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 
@@ -91,6 +129,12 @@ func gRPCListen(app config.AppConfig, aw auth.Middleware) {
 	if gwServer != nil {
 		gwServer.Shutdown(ctx)
 	}
+	if hookServer != nil {
+		hookServer.Shutdown(ctx)
+	}
+	if metricsServer != nil {
+		metricsServer.Shutdown(ctx)
+	}
 }
 
 func registerHealth(s *grpc.Server) {
@@ -99,7 +143,7 @@ func registerHealth(s *grpc.Server) {
 	grpc_health_v1.RegisterHealthServer(s, healthServer)
 }
 
-func setupGrpcOptions(app config.AppConfig, aw auth.Middleware) []grpc.ServerOption {
+func setupGrpcOptions(app config.AppConfig, aw auth.Middleware, policy *auth.ScopePolicy, proxies auth.TrustedProxies, addressLimiter, limiter *ratelimit.Limiter) []grpc.ServerOption {
 	// Logrus entry is used, allowing pre-definition of certain fields by the user.
 	// See example setup here https://github.com/grpc-ecosystem/go-grpc-middleware/blob/master/logging/logrus/examples_test.go
 	opts := []grpc_logrus.Option{
@@ -121,15 +165,23 @@ func setupGrpcOptions(app config.AppConfig, aw auth.Middleware) []grpc.ServerOpt
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_ctxtags.UnaryServerInterceptor(),
 			grpc_logrus.UnaryServerInterceptor(log.NewEntry().GetUnderneath(), opts...),
+			errs.UnaryServerInterceptor(),
+			// recover early so a panic in auth is logged and mapped like any error
+			grpc_recovery.UnaryServerInterceptor(),
+			// resolve the client address once, believing only trusted proxies
+			auth.ClientInfoInterceptor(proxies),
+			// turn floods away before they cost an identity provider call
+			addressLimiter.AddressInterceptor(),
 			aw.InterceptorNew(),
+			limiter.Interceptor(),
+			policy.Interceptor(),
 			grpc_validator.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(),
 		)),
 	}
 
 	if !app.IsLocalEnv() {
 		// load tls for grpc
-		tlsCredentials, err := loadCredentials()
+		tlsCredentials, err := loadCredentials(app)
 		if err != nil {
 			log.Fatalf("Cannot load TLS credentials: %v", err)
 		}
@@ -140,16 +192,34 @@ func setupGrpcOptions(app config.AppConfig, aw auth.Middleware) []grpc.ServerOpt
 	return grpcOptions
 }
 
-func loadCredentials() (credentials.TransportCredentials, error) {
-	cert, err := tls.LoadX509KeyPair("server.crt", "server.key")
+// loadCredentials serves the configured certificate. With a client CA bundle
+// it also asks callers for a certificate and verifies any they present, which
+// is how internal services are identified. Callers without one still reach
+// the service with their user credentials.
+func loadCredentials(app config.AppConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(app.TlsCertFile, app.TlsKeyFile)
 	if err != nil {
 		return nil, err
 	}
 
-	return credentials.NewTLS(
-		&tls.Config{
-			Certificates: []tls.Certificate{cert},
-			ClientAuth:   tls.NoClientCert,
-		},
-	), nil
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.NoClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if app.TlsClientCaFile != "" {
+		bundle, err := os.ReadFile(app.TlsClientCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client ca bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in client ca bundle %s", app.TlsClientCaFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
 }
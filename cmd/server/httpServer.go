@@ -18,12 +18,17 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/hooks"
 	"github.com/coinbase-samples/ib-usermgr-go/log"
 	v1 "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
 	"github.com/gorilla/handlers"
@@ -46,7 +51,12 @@ func profileConn(app config.AppConfig) (*grpc.ClientConn, error) {
 	return conn, err
 }
 
-func setupHttp(app config.AppConfig, grpcServer *grpc.Server) (*http.Server, error) {
+// localCognitoPath is where the fake user pool answers sign ins in the local
+// environment, e.g. aws cognito-idp initiate-auth --endpoint-url
+// http://localhost:8451/local/cognito
+const localCognitoPath = "/local/cognito"
+
+func setupHttp(app config.AppConfig, grpcServer *grpc.Server, localCognito http.Handler) (*http.Server, error) {
 	log.Debug("dialing profile")
 	pConn, err := profileConn(app)
 	if err != nil {
@@ -54,7 +64,7 @@ func setupHttp(app config.AppConfig, grpcServer *grpc.Server) (*http.Server, err
 	}
 	log.Debug("Connected to profile")
 
-	gwmux := runtime.NewServeMux(runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+	gwmux := runtime.NewServeMux(runtime.WithErrorHandler(errs.GatewayErrorHandler), runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
 		md := make(map[string]string)
 		if method, ok := runtime.RPCMethod(ctx); ok {
 			md["method"] = method // /grpc.gateway.examples.internal.proto.examplepb.LoginService/Login
@@ -62,6 +72,9 @@ func setupHttp(app config.AppConfig, grpcServer *grpc.Server) (*http.Server, err
 		if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
 			md["pattern"] = pattern // /v1/example/login
 		}
+		if grant := r.Header.Get(auth.ImpersonationHeader); grant != "" {
+			md[auth.ImpersonationHeader] = grant
+		}
 		return metadata.New(md)
 	}))
 
@@ -71,13 +84,29 @@ func setupHttp(app config.AppConfig, grpcServer *grpc.Server) (*http.Server, err
 		io.WriteString(w, "ok\n")
 	})
 
+	if app.HookSecret != "" {
+		postConfirmation := postConfirmationHook(app)
+		gwmux.HandlePath("POST", hooks.PostConfirmationPath, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			postConfirmation.ServeHTTP(w, r)
+		})
+	}
+
 	// Register Service Handlers
 	if err := v1.RegisterProfileServiceHandler(context.Background(), gwmux, pConn); err != nil {
 		log.Fatalf("Failed to register profile: %v", err)
 	}
 
+	var handler http.Handler = gwmux
+	if localCognito != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/", gwmux)
+		mux.Handle(localCognitoPath, localCognito)
+		mux.Handle(localCognitoPath+"/", localCognito)
+		handler = mux
+	}
+
 	gwServer := &http.Server{
-		Handler:      makeHttpHandler(gwmux, app),
+		Handler:      makeHttpHandler(handler, app),
 		Addr:         fmt.Sprintf(":%s", app.Port),
 		WriteTimeout: 40 * time.Second,
 		ReadTimeout:  40 * time.Second,
@@ -103,8 +132,9 @@ func setupHttp(app config.AppConfig, grpcServer *grpc.Server) (*http.Server, err
 }
 
 func makeHttpHandler(gwmux http.Handler, app config.AppConfig) http.Handler {
-	headersOk := handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization"})
-	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "OPTIONS"})
+	headersOk := handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization", "X-Impersonation-Grant", "X-Amz-Target", "X-Amz-User-Agent"})
+	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
+	exposedOk := handlers.ExposedHeaders([]string{"Retry-After"})
 	origins := []string{
 		fmt.Sprintf("https://localhost:%s", app.Port),
 		fmt.Sprintf("http://localhost:%s", app.Port),
@@ -115,5 +145,66 @@ func makeHttpHandler(gwmux http.Handler, app config.AppConfig) http.Handler {
 	originsOk := handlers.AllowedOrigins(origins)
 
 	log.Debugf("starting http - %v - %v - %v", originsOk, headersOk, methodsOk)
-	return handlers.CORS(originsOk, headersOk, methodsOk)(gwmux)
+	return handlers.CORS(originsOk, headersOk, methodsOk, exposedOk)(gwmux)
+}
+
+func postConfirmationHook(app config.AppConfig) *hooks.PostConfirmation {
+	return &hooks.PostConfirmation{Repo: dba.Repo, Profiles: dba.Profiles, Secret: app.HookSecret}
+}
+
+// setupMetricsHttp serves expvar metrics such as auth_token_cache on a port
+// of its own, which is kept off the public load balancer
+func setupMetricsHttp(app config.AppConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	metricsServer := &http.Server{
+		Handler:      mux,
+		Addr:         fmt.Sprintf(":%s", app.MetricsPort),
+		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  10 * time.Second,
+	}
+
+	log.Debugf("started http metrics on - %s", app.MetricsPort)
+
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("ListenAndServe: %v", err)
+		}
+	}()
+
+	return metricsServer
+}
+
+// setupHookHttp serves the hook endpoints over TLS on their own port. The
+// gateway only runs in the local environment, so deployed servers need this
+// listener for relays that cannot invoke the Lambda trigger.
+func setupHookHttp(app config.AppConfig) *http.Server {
+	mux := http.NewServeMux()
+	postConfirmation := postConfirmationHook(app)
+	mux.HandleFunc(hooks.PostConfirmationPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		postConfirmation.ServeHTTP(w, r)
+	})
+
+	hookServer := &http.Server{
+		Handler:      mux,
+		Addr:         fmt.Sprintf(":%s", app.HookPort),
+		WriteTimeout: 40 * time.Second,
+		ReadTimeout:  40 * time.Second,
+	}
+
+	log.Debugf("started https hooks on - %s", app.HookPort)
+
+	go func() {
+		if err := hookServer.ListenAndServeTLS(app.TlsCertFile, app.TlsKeyFile); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("ListenAndServeTLS: %v", err)
+		}
+	}()
+
+	return hookServer
 }
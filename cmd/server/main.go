@@ -18,12 +18,15 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/cognitofake"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
 	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/handlers"
 	"github.com/coinbase-samples/ib-usermgr-go/log"
 )
 
@@ -43,14 +46,58 @@ func main() {
 		log.Fatalf("cannot read aws config: %v", err)
 	}
 
-	// Setup cognito client
-	cip := auth.InitAuth(&app, cfg)
-	aw := auth.Middleware{Cip: cip}
-
 	// Setup database
 	repo := dba.NewRepo(&app, cfg)
 	dba.NewDBA(repo)
+	profiles, err := dba.NewProfileStore(context.Background(), &app, repo)
+	if err != nil {
+		log.Fatalf("cannot setup profile store: %v", err)
+	}
+	dba.NewProfileDBA(profiles)
+	if err := dba.CheckTables(context.Background(), &app, cfg); err != nil {
+		log.Fatalf("cannot reach dynamodb: %v", err)
+	}
+
+	// Setup cognito client
+	cip := auth.InitAuth(&app, cfg)
+	var tokens auth.AuthClient = cip
+	var localCognito http.Handler
+	if app.IdentityProvider == config.IdentityProviderFake {
+		// the in-memory pool answers both token checks and admin calls
+		pool, err := cognitofake.Load(app.CognitoFakeSeedFile, app.ClientId)
+		if err != nil {
+			log.Fatalf("cannot setup fake cognito: %v", err)
+		}
+		cip.Admin = pool
+		tokens = pool
+		localCognito = pool
+	}
+	identity, err := auth.NewIdentityProvider(context.Background(), &app, tokens)
+	if err != nil {
+		log.Fatalf("cannot setup identity provider: %v", err)
+	}
+	aw := auth.Middleware{
+		Identity:             identity,
+		Sessions:             repo,
+		Revocations:          repo,
+		ApiKeys:              repo,
+		Grants:               repo,
+		Memberships:          repo,
+		Services:             app.GetServiceIdentities(),
+		GroupRoles:           app.GetGroupRoles(),
+		SessionTouchInterval: app.SessionTouchInterval,
+		MembershipCacheTtl:   app.MembershipCacheTtl,
+		RevocationCacheTtl:   app.RevocationCacheTtl,
+	}
+
+	// Keep the user pool's copy of profile attributes current
+	var attributes handlers.AttributeSyncer
+	if app.UsesCognitoApi() {
+		synchronizer := auth.NewAttributeSynchronizer(cip, repo)
+		go synchronizer.Run(context.Background(), app.AttributeSyncRetryInterval)
+		attributes = synchronizer
+	}
 
 	// Start gRPC Server
-	gRPCListen(app, aw)
+	gRPCListen(app, aw, cip, attributes, localCognito)
 }
@@ -0,0 +1,155 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cognitofake
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	targetPrefix = "AWSCognitoIdentityProviderService."
+	contentType  = "application/x-amz-json-1.1"
+	maxBodyBytes = 64 << 10
+)
+
+type initiateAuthRequest struct {
+	AuthFlow       string
+	ClientId       string
+	AuthParameters map[string]string
+}
+
+type authenticationResult struct {
+	AccessToken  string
+	ExpiresIn    int32
+	RefreshToken string `json:",omitempty"`
+	TokenType    string
+}
+
+type accessTokenRequest struct {
+	AccessToken string
+}
+
+type attribute struct {
+	Name  string
+	Value string
+}
+
+// ServeHTTP answers the Cognito JSON protocol for the calls a client makes
+// itself, InitiateAuth, GetUser and GlobalSignOut, so an SDK or the AWS CLI
+// pointed at the pool with an endpoint override can sign in. Admin calls are
+// only available in process.
+func (p *Pool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "InvalidAction", "only POST is supported")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), targetPrefix) {
+	case "InitiateAuth":
+		var req initiateAuthRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		out, err := p.InitiateAuth(r.Context(), &cip.InitiateAuthInput{
+			AuthFlow:       types.AuthFlowType(req.AuthFlow),
+			ClientId:       aws.String(req.ClientId),
+			AuthParameters: req.AuthParameters,
+		})
+		if err != nil {
+			writeApiError(w, err)
+			return
+		}
+		result := out.AuthenticationResult
+		writeJson(w, map[string]interface{}{
+			"AuthenticationResult": authenticationResult{
+				AccessToken:  aws.ToString(result.AccessToken),
+				ExpiresIn:    result.ExpiresIn,
+				RefreshToken: aws.ToString(result.RefreshToken),
+				TokenType:    aws.ToString(result.TokenType),
+			},
+			"ChallengeParameters": map[string]string{},
+		})
+	case "GetUser":
+		var req accessTokenRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		out, err := p.GetUser(r.Context(), &cip.GetUserInput{AccessToken: aws.String(req.AccessToken)})
+		if err != nil {
+			writeApiError(w, err)
+			return
+		}
+		attributes := make([]attribute, 0, len(out.UserAttributes))
+		for _, a := range out.UserAttributes {
+			attributes = append(attributes, attribute{Name: aws.ToString(a.Name), Value: aws.ToString(a.Value)})
+		}
+		writeJson(w, map[string]interface{}{
+			"Username":       aws.ToString(out.Username),
+			"UserAttributes": attributes,
+		})
+	case "GlobalSignOut":
+		var req accessTokenRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if _, err := p.GlobalSignOut(r.Context(), &cip.GlobalSignOutInput{AccessToken: aws.String(req.AccessToken)}); err != nil {
+			writeApiError(w, err)
+			return
+		}
+		writeJson(w, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusBadRequest, "UnknownOperationException", "operation is not supported by the fake user pool")
+	}
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "SerializationException", "could not parse request body")
+		return false
+	}
+	return true
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeApiError(w http.ResponseWriter, err error) {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		writeError(w, http.StatusBadRequest, apiErr.ErrorCode(), apiErr.ErrorMessage())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "InternalErrorException", err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Amzn-ErrorType", code)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": message})
+}
@@ -0,0 +1,411 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cognitofake is an in-memory stand in for a Cognito user pool. It
+// answers the user pool calls the service makes, so tests and local runs
+// need no AWS account.
+package cognitofake
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/google/uuid"
+)
+
+// signInScope is the scope Cognito puts on tokens from a first party sign in
+const signInScope = "aws.cognito.signin.user.admin"
+
+// User is a user of the pool as it appears in a seed file
+type User struct {
+	Username string `json:"username"`
+	// Sub is generated when left empty
+	Sub        string            `json:"sub"`
+	Password   string            `json:"password"`
+	Attributes map[string]string `json:"attributes"`
+	Groups     []string          `json:"groups"`
+}
+
+// Seed is the format of a seed file, e.g.
+// {"users": [{"username": "d0", "sub": "...", "password": "...",
+// "attributes": {"email": "...", "custom:org_id": "..."}, "groups": ["org-admins"]}]}
+type Seed struct {
+	Users []User `json:"users"`
+}
+
+// signIn is an authentication, every token refreshed from it shares its
+// origin jti and auth time
+type signIn struct {
+	sub       string
+	originJti string
+	authTime  time.Time
+}
+
+type accessToken struct {
+	signIn
+	expiresAt time.Time
+}
+
+// Pool is an in-memory user pool. Its access tokens are shaped like Cognito
+// JWTs so the claims the service reads are present, but they are unsigned
+// and only valid because the pool issued them.
+type Pool struct {
+	ClientId   string
+	UserPoolId string
+	TokenTtl   time.Duration
+
+	mu            sync.Mutex
+	users         map[string]*User
+	usernames     map[string]string
+	accessTokens  map[string]accessToken
+	refreshTokens map[string]signIn
+	now           func() time.Time
+}
+
+// New returns an empty pool whose tokens are issued to clientId
+func New(clientId string) *Pool {
+	return &Pool{
+		ClientId:      clientId,
+		UserPoolId:    "local",
+		TokenTtl:      time.Hour,
+		users:         make(map[string]*User),
+		usernames:     make(map[string]string),
+		accessTokens:  make(map[string]accessToken),
+		refreshTokens: make(map[string]signIn),
+		now:           time.Now,
+	}
+}
+
+// Load returns a pool holding the users of the seed file at path
+func Load(path, clientId string) (*Pool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read cognito seed: %w", err)
+	}
+
+	var seed Seed
+	if err = json.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("could not parse cognito seed: %w", err)
+	}
+
+	pool := New(clientId)
+	for _, user := range seed.Users {
+		if _, err = pool.AddUser(user); err != nil {
+			return nil, fmt.Errorf("could not seed %q: %w", user.Username, err)
+		}
+	}
+	return pool, nil
+}
+
+// AddUser adds a confirmed user to the pool and returns it with its sub
+func (p *Pool) AddUser(user User) (User, error) {
+	if user.Username == "" {
+		return User{}, &types.InvalidParameterException{Message: aws.String("username is required")}
+	}
+	if user.Sub == "" {
+		user.Sub = uuid.New().String()
+	}
+
+	attributes := make(map[string]string, len(user.Attributes))
+	for name, value := range user.Attributes {
+		attributes[name] = value
+	}
+	user.Attributes = attributes
+	user.Groups = append([]string{}, user.Groups...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.users[user.Sub]; exists {
+		return User{}, &types.UsernameExistsException{Message: aws.String("User account already exists.")}
+	}
+	if _, exists := p.usernames[user.Username]; exists {
+		return User{}, &types.UsernameExistsException{Message: aws.String("User account already exists.")}
+	}
+	p.users[user.Sub] = &user
+	p.usernames[user.Username] = user.Sub
+	return user, nil
+}
+
+// IssueToken signs the user in without a password and returns an access
+// token, for tests that only need a valid caller
+func (p *Pool) IssueToken(username string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.lookup(username)
+	if err != nil {
+		return "", err
+	}
+	token, _, err := p.mint(user, p.newSignIn(user))
+	return token, err
+}
+
+// InitiateAuth supports the USER_PASSWORD_AUTH and REFRESH_TOKEN_AUTH flows
+func (p *Pool) InitiateAuth(ctx context.Context, params *cip.InitiateAuthInput, optFns ...func(*cip.Options)) (*cip.InitiateAuthOutput, error) {
+	if aws.ToString(params.ClientId) != p.ClientId {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("User pool client does not exist.")}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		user         *User
+		session      signIn
+		refreshToken string
+	)
+	switch params.AuthFlow {
+	case types.AuthFlowTypeUserPasswordAuth:
+		found, err := p.lookup(params.AuthParameters["USERNAME"])
+		if err != nil || subtle.ConstantTimeCompare([]byte(found.Password), []byte(params.AuthParameters["PASSWORD"])) != 1 {
+			return nil, &types.NotAuthorizedException{Message: aws.String("Incorrect username or password.")}
+		}
+		user, session = found, p.newSignIn(found)
+		if refreshToken, err = randomToken(); err != nil {
+			return nil, err
+		}
+		p.refreshTokens[refreshToken] = session
+	case types.AuthFlowTypeRefreshTokenAuth, types.AuthFlowTypeRefreshToken:
+		var ok bool
+		if session, ok = p.refreshTokens[params.AuthParameters["REFRESH_TOKEN"]]; !ok {
+			return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Refresh Token")}
+		}
+		user = p.users[session.sub]
+	default:
+		return nil, &types.InvalidParameterException{Message: aws.String(fmt.Sprintf("auth flow %s is not supported", params.AuthFlow))}
+	}
+
+	token, expiresIn, err := p.mint(user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.AuthenticationResultType{
+		AccessToken: aws.String(token),
+		ExpiresIn:   expiresIn,
+		TokenType:   aws.String("Bearer"),
+	}
+	if refreshToken != "" {
+		result.RefreshToken = aws.String(refreshToken)
+	}
+	return &cip.InitiateAuthOutput{AuthenticationResult: result}, nil
+}
+
+// GetUser returns the user an access token was issued to
+func (p *Pool) GetUser(ctx context.Context, params *cip.GetUserInput, optFns ...func(*cip.Options)) (*cip.GetUserOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.authenticate(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cip.GetUserOutput{
+		Username:       aws.String(user.Username),
+		UserAttributes: user.attributeTypes(),
+	}, nil
+}
+
+// attributeTypes lists the user's attributes as Cognito returns them, sub
+// first and the rest by name
+func (u *User) attributeTypes() []types.AttributeType {
+	names := make([]string, 0, len(u.Attributes))
+	for name := range u.Attributes {
+		if name != "sub" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	attributes := []types.AttributeType{{Name: aws.String("sub"), Value: aws.String(u.Sub)}}
+	for _, name := range names {
+		attributes = append(attributes, types.AttributeType{Name: aws.String(name), Value: aws.String(u.Attributes[name])})
+	}
+	return attributes
+}
+
+// GlobalSignOut ends every sign in of the token's user
+func (p *Pool) GlobalSignOut(ctx context.Context, params *cip.GlobalSignOutInput, optFns ...func(*cip.Options)) (*cip.GlobalSignOutOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.authenticate(aws.ToString(params.AccessToken))
+	if err != nil {
+		return nil, err
+	}
+	p.signOut(user.Sub)
+	return &cip.GlobalSignOutOutput{}, nil
+}
+
+// AdminGetUser returns the user and their attributes
+func (p *Pool) AdminGetUser(ctx context.Context, params *cip.AdminGetUserInput, optFns ...func(*cip.Options)) (*cip.AdminGetUserOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	return &cip.AdminGetUserOutput{
+		Username:       aws.String(user.Username),
+		UserAttributes: user.attributeTypes(),
+	}, nil
+}
+
+// AdminUpdateUserAttributes overwrites attributes of the user
+func (p *Pool) AdminUpdateUserAttributes(ctx context.Context, params *cip.AdminUpdateUserAttributesInput, optFns ...func(*cip.Options)) (*cip.AdminUpdateUserAttributesOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range params.UserAttributes {
+		if aws.ToString(attribute.Name) == "sub" {
+			return nil, &types.InvalidParameterException{Message: aws.String("Cannot modify the non-mutable attribute sub")}
+		}
+	}
+	for _, attribute := range params.UserAttributes {
+		user.Attributes[aws.ToString(attribute.Name)] = aws.ToString(attribute.Value)
+	}
+	return &cip.AdminUpdateUserAttributesOutput{}, nil
+}
+
+// AdminUserGlobalSignOut ends every sign in of the user. Unlike Cognito it
+// also invalidates access tokens already issued.
+func (p *Pool) AdminUserGlobalSignOut(ctx context.Context, params *cip.AdminUserGlobalSignOutInput, optFns ...func(*cip.Options)) (*cip.AdminUserGlobalSignOutOutput, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.lookup(aws.ToString(params.Username))
+	if err != nil {
+		return nil, err
+	}
+	p.signOut(user.Sub)
+	return &cip.AdminUserGlobalSignOutOutput{}, nil
+}
+
+// Attribute returns the current value of a user attribute
+func (p *Pool) Attribute(username, name string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	user, err := p.lookup(username)
+	if err != nil {
+		return "", false
+	}
+	value, ok := user.Attributes[name]
+	return value, ok
+}
+
+// lookup finds a user by username or sub, like Cognito's admin calls
+func (p *Pool) lookup(username string) (*User, error) {
+	if user, ok := p.users[username]; ok {
+		return user, nil
+	}
+	if sub, ok := p.usernames[username]; ok {
+		return p.users[sub], nil
+	}
+	return nil, &types.UserNotFoundException{Message: aws.String("User does not exist.")}
+}
+
+func (p *Pool) authenticate(token string) (*User, error) {
+	issued, ok := p.accessTokens[token]
+	if !ok || !p.now().Before(issued.expiresAt) {
+		delete(p.accessTokens, token)
+		return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Access Token")}
+	}
+	user, ok := p.users[issued.sub]
+	if !ok {
+		return nil, &types.NotAuthorizedException{Message: aws.String("Invalid Access Token")}
+	}
+	return user, nil
+}
+
+func (p *Pool) signOut(sub string) {
+	for token, issued := range p.accessTokens {
+		if issued.sub == sub {
+			delete(p.accessTokens, token)
+		}
+	}
+	for token, session := range p.refreshTokens {
+		if session.sub == sub {
+			delete(p.refreshTokens, token)
+		}
+	}
+}
+
+func (p *Pool) newSignIn(user *User) signIn {
+	return signIn{sub: user.Sub, originJti: uuid.New().String(), authTime: p.now()}
+}
+
+// mint issues an access token for a sign in, returning it with its lifetime
+// in seconds
+func (p *Pool) mint(user *User, session signIn) (string, int32, error) {
+	now := p.now()
+	claims := map[string]interface{}{
+		"sub":        user.Sub,
+		"iss":        "https://cognito-idp.local/" + p.UserPoolId,
+		"client_id":  p.ClientId,
+		"origin_jti": session.originJti,
+		"token_use":  "access",
+		"scope":      signInScope,
+		"auth_time":  session.authTime.Unix(),
+		"iat":        now.Unix(),
+		"exp":        now.Add(p.TokenTtl).Unix(),
+		"jti":        uuid.New().String(),
+		"username":   user.Username,
+	}
+	if len(user.Groups) > 0 {
+		claims["cognito:groups"] = user.Groups
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", 0, fmt.Errorf("could not marshal claims: %w", err)
+	}
+	secret, err := randomToken()
+	if err != nil {
+		return "", 0, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + "." + secret
+	p.accessTokens[token] = accessToken{signIn: session, expiresAt: now.Add(p.TokenTtl)}
+	return token, int32(p.TokenTtl / time.Second), nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,229 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cognitofake
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cip "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+const (
+	testClientId = "local"
+	testSub      = "37d10e18-34a2-4bd2-b7bc-b8e6dd6358f1"
+)
+
+func signInUser(t *testing.T, pool *Pool, username, password string) *types.AuthenticationResultType {
+	t.Helper()
+	out, err := pool.InitiateAuth(context.Background(), &cip.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		ClientId:       aws.String(testClientId),
+		AuthParameters: map[string]string{"USERNAME": username, "PASSWORD": password},
+	})
+	if err != nil {
+		t.Fatalf("could not sign in: %v", err)
+	}
+	return out.AuthenticationResult
+}
+
+func getUser(pool *Pool, token string) (*cip.GetUserOutput, error) {
+	return pool.GetUser(context.Background(), &cip.GetUserInput{AccessToken: aws.String(token)})
+}
+
+func TestLoadBundledSeed(t *testing.T) {
+	pool, err := Load("../cognito-seed.json", testClientId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := signInUser(t, pool, "d0", "local-password")
+	out, err := getUser(pool, aws.ToString(result.AccessToken))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aws.ToString(out.Username) != "d0" || aws.ToString(out.UserAttributes[0].Value) != testSub {
+		t.Fatalf("unexpected user: %+v", out)
+	}
+	if org, _ := pool.Attribute(testSub, "custom:org_id"); org != "demo-org" {
+		t.Fatalf("expected seeded org, got %q", org)
+	}
+}
+
+func TestSignInAndRefresh(t *testing.T) {
+	pool := New(testClientId)
+	if _, err := pool.AddUser(User{Username: "bob", Sub: testSub, Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := pool.InitiateAuth(context.Background(), &cip.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		ClientId:       aws.String(testClientId),
+		AuthParameters: map[string]string{"USERNAME": "bob", "PASSWORD": "wrong"},
+	})
+	var notAuthorized *types.NotAuthorizedException
+	if !errors.As(err, &notAuthorized) {
+		t.Fatalf("expected wrong password to be rejected, got %v", err)
+	}
+
+	first := signInUser(t, pool, "bob", "secret")
+	refreshed, err := pool.InitiateAuth(context.Background(), &cip.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeRefreshTokenAuth,
+		ClientId:       aws.String(testClientId),
+		AuthParameters: map[string]string{"REFRESH_TOKEN": aws.ToString(first.RefreshToken)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second := refreshed.AuthenticationResult
+	if aws.ToString(second.AccessToken) == aws.ToString(first.AccessToken) || second.RefreshToken != nil {
+		t.Fatal("expected a new access token and no new refresh token")
+	}
+
+	if _, err := pool.GlobalSignOut(context.Background(), &cip.GlobalSignOutInput{AccessToken: second.AccessToken}); err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{aws.ToString(first.AccessToken), aws.ToString(second.AccessToken)} {
+		if _, err := getUser(pool, token); !errors.As(err, &notAuthorized) {
+			t.Fatalf("expected token to be invalid after sign out, got %v", err)
+		}
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	pool := New(testClientId)
+	if _, err := pool.AddUser(User{Username: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	token, err := pool.IssueToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	var notAuthorized *types.NotAuthorizedException
+	if _, err := getUser(pool, token); !errors.As(err, &notAuthorized) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestAdminCalls(t *testing.T) {
+	pool := New(testClientId)
+	if _, err := pool.AddUser(User{Username: "bob", Sub: testSub, Attributes: map[string]string{"email": "bob@example.com"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.AddUser(User{Username: "bob"}); err == nil {
+		t.Fatal("expected duplicate username to be rejected")
+	}
+
+	// the service addresses users by sub
+	if _, err := pool.AdminUpdateUserAttributes(context.Background(), &cip.AdminUpdateUserAttributesInput{
+		Username:       aws.String(testSub),
+		UserAttributes: []types.AttributeType{{Name: aws.String("email"), Value: aws.String("robert@example.com")}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email, _ := pool.Attribute("bob", "email"); email != "robert@example.com" {
+		t.Fatalf("expected email to be updated, got %q", email)
+	}
+
+	_, err := pool.AdminUpdateUserAttributes(context.Background(), &cip.AdminUpdateUserAttributesInput{
+		Username: aws.String("nobody"),
+	})
+	var notFound *types.UserNotFoundException
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected unknown user to be rejected, got %v", err)
+	}
+
+	token, err := pool.IssueToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.AdminUserGlobalSignOut(context.Background(), &cip.AdminUserGlobalSignOutInput{Username: aws.String(testSub)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getUser(pool, token); err == nil {
+		t.Fatal("expected token to be invalid after admin sign out")
+	}
+}
+
+func TestServeHTTPWithSdk(t *testing.T) {
+	pool := New(testClientId)
+	if _, err := pool.AddUser(User{Username: "bob", Sub: testSub, Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(pool)
+	defer srv.Close()
+
+	client := cip.New(cip.Options{
+		Region:           "us-east-1",
+		Credentials:      aws.AnonymousCredentials{},
+		EndpointResolver: cip.EndpointResolverFromURL(srv.URL + "/local/cognito"),
+	})
+	ctx := context.Background()
+
+	auth, err := client.InitiateAuth(ctx, &cip.InitiateAuthInput{
+		AuthFlow:       types.AuthFlowTypeUserPasswordAuth,
+		ClientId:       aws.String(testClientId),
+		AuthParameters: map[string]string{"USERNAME": "bob", "PASSWORD": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth.AuthenticationResult.ExpiresIn != 3600 || auth.AuthenticationResult.RefreshToken == nil {
+		t.Fatalf("unexpected result: %+v", auth.AuthenticationResult)
+	}
+
+	user, err := client.GetUser(ctx, &cip.GetUserInput{AccessToken: auth.AuthenticationResult.AccessToken})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aws.ToString(user.Username) != "bob" || aws.ToString(user.UserAttributes[0].Value) != testSub {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	_, err = client.GetUser(ctx, &cip.GetUserInput{AccessToken: aws.String("not-a-token")})
+	var notAuthorized *types.NotAuthorizedException
+	if !errors.As(err, &notAuthorized) {
+		t.Fatalf("expected the sdk to see NotAuthorizedException, got %v", err)
+	}
+}
+
+func TestAdminGetUser(t *testing.T) {
+	pool := New(testClientId)
+	if _, err := pool.AddUser(User{Username: "bob", Sub: testSub, Password: "secret", Attributes: map[string]string{"email": "bob@example.com"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := pool.AdminGetUser(context.Background(), &cip.AdminGetUserInput{Username: aws.String(testSub)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aws.ToString(out.Username) != "bob" || len(out.UserAttributes) != 2 || aws.ToString(out.UserAttributes[1].Value) != "bob@example.com" {
+		t.Fatalf("unexpected user: %+v", out)
+	}
+
+	var notFound *types.UserNotFoundException
+	if _, err = pool.AdminGetUser(context.Background(), &cip.AdminGetUserInput{Username: aws.String("nobody")}); !errors.As(err, &notFound) {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertApiKeyToProto(k model.ApiKey) *profile.ApiKey {
+	key := &profile.ApiKey{
+		KeyId:     k.KeyId,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+		ExpiresAt: timestamppb.New(k.ExpiresAt),
+	}
+	if k.RevokedAt != nil {
+		key.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return key
+}
+
+func ConvertApiKeysToProto(keys []model.ApiKey) []*profile.ApiKey {
+	out := make([]*profile.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, ConvertApiKeyToProto(k))
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertConsentToProto(c model.Consent) *profile.Consent {
+	consent := &profile.Consent{
+		ConsentId:       c.ConsentId,
+		UserId:          c.UserId,
+		DocumentType:    c.DocumentType,
+		DocumentVersion: c.DocumentVersion,
+		AcceptedAt:      timestamppb.New(c.AcceptedAt),
+		IpAddress:       c.IpAddress,
+		UserAgent:       c.UserAgent,
+	}
+	if c.WithdrawnAt != nil {
+		consent.WithdrawnAt = timestamppb.New(*c.WithdrawnAt)
+	}
+	return consent
+}
+
+func ConvertConsentsToProto(consents []model.Consent) []*profile.Consent {
+	out := make([]*profile.Consent, 0, len(consents))
+	for _, c := range consents {
+		out = append(out, ConvertConsentToProto(c))
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/dedupe"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+)
+
+func ConvertDuplicateCandidatesToProto(candidates []dedupe.Candidate) []*profile.DuplicateCandidate {
+	out := make([]*profile.DuplicateCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, &profile.DuplicateCandidate{
+			First:   ConvertMemberToProto(c.First),
+			Second:  ConvertMemberToProto(c.Second),
+			Score:   c.Score,
+			Reasons: c.Reasons,
+		})
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertImpersonationGrantToProto(g model.ImpersonationGrant) *profile.ImpersonationGrant {
+	grant := &profile.ImpersonationGrant{
+		GrantId:      g.GrantId,
+		ActorId:      g.ActorId,
+		SubjectId:    g.SubjectId,
+		SubjectOrgId: g.SubjectOrgId,
+		Reason:       g.Reason,
+		CreatedAt:    timestamppb.New(g.CreatedAt),
+		ExpiresAt:    timestamppb.New(g.ExpiresAt),
+	}
+	if g.RevokedAt != nil {
+		grant.RevokedAt = timestamppb.New(*g.RevokedAt)
+	}
+	return grant
+}
+
+func ConvertImpersonationGrantsToProto(grants []model.ImpersonationGrant) []*profile.ImpersonationGrant {
+	out := make([]*profile.ImpersonationGrant, 0, len(grants))
+	for _, g := range grants {
+		out = append(out, ConvertImpersonationGrantToProto(g))
+	}
+	return out
+}
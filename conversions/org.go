@@ -0,0 +1,59 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertInvitationToProto(i model.Invitation) *profile.Invitation {
+	invitation := &profile.Invitation{
+		InvitationId: i.InvitationId,
+		OrgId:        i.OrgId,
+		Email:        i.Email,
+		Role:         i.Role,
+		InvitedBy:    i.InvitedBy,
+		CreatedAt:    timestamppb.New(i.CreatedAt),
+		ExpiresAt:    timestamppb.New(i.ExpiresAt),
+	}
+	if i.AcceptedAt != nil {
+		invitation.AcceptedAt = timestamppb.New(*i.AcceptedAt)
+	}
+	return invitation
+}
+
+func ConvertMembersToProto(profiles []model.ProfileResponse) []*profile.Member {
+	out := make([]*profile.Member, 0, len(profiles))
+	for _, p := range profiles {
+		out = append(out, ConvertMemberToProto(p))
+	}
+	return out
+}
+
+func ConvertMemberToProto(p model.ProfileResponse) *profile.Member {
+	return &profile.Member{
+		UserId:     p.UserId,
+		Email:      p.Email,
+		Name:       p.Name,
+		UserName:   p.UserName,
+		Roles:      p.Roles,
+		CreatedAt:  timestamppb.New(p.CreatedAt),
+		MergedInto: p.MergedInto,
+	}
+}
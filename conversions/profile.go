@@ -34,6 +34,8 @@ func ConvertReadProfileToProto(p model.ProfileResponse) profile.ReadProfileRespo
 		DateOfBirth: p.DateOfBirth,
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		OrgId:       p.OrgId,
+		MergedInto:  p.MergedInto,
 	}
 }
 
@@ -61,5 +63,34 @@ func ConvertUpdateProfileToProto(p model.ProfileResponse) profile.UpdateProfileR
 		DateOfBirth: p.DateOfBirth,
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		OrgId:       p.OrgId,
+	}
+}
+
+func ConvertCreateProfileToModel(p *profile.CreateProfileRequest) model.UpdateProfileRequest {
+	return model.UpdateProfileRequest{
+		UserId:      p.Id,
+		Email:       p.Email,
+		Name:        p.Name,
+		LegalName:   p.LegalName,
+		UserName:    p.UserName,
+		Address:     p.Address,
+		DateOfBirth: p.DateOfBirth,
+	}
+}
+
+func ConvertCreateProfileToProto(p model.ProfileResponse) profile.CreateProfileResponse {
+	return profile.CreateProfileResponse{
+		UserId:      p.UserId,
+		Email:       p.Email,
+		Name:        p.Name,
+		LegalName:   p.LegalName,
+		UserName:    p.UserName,
+		Roles:       p.Roles,
+		Address:     p.Address,
+		DateOfBirth: p.DateOfBirth,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		OrgId:       p.OrgId,
 	}
 }
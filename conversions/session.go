@@ -0,0 +1,47 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conversions
+
+import (
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ConvertSessionToProto(s model.Session, currentSessionId string) *profile.Session {
+	session := &profile.Session{
+		SessionId:   s.SessionId,
+		TokenId:     s.TokenId,
+		IpAddress:   s.IpAddress,
+		UserAgent:   s.UserAgent,
+		FirstSeenAt: timestamppb.New(s.FirstSeenAt),
+		LastSeenAt:  timestamppb.New(s.LastSeenAt),
+		Current:     currentSessionId != "" && s.SessionId == currentSessionId,
+	}
+	if s.RevokedAt != nil {
+		session.RevokedAt = timestamppb.New(*s.RevokedAt)
+	}
+	return session
+}
+
+func ConvertSessionsToProto(sessions []model.Session, currentSessionId string) []*profile.Session {
+	out := make([]*profile.Session, 0, len(sessions))
+	for _, s := range sessions {
+		out = append(out, ConvertSessionToProto(s, currentSessionId))
+	}
+	return out
+}
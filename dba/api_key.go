@@ -0,0 +1,149 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// ApiKeyUserIndex is the global secondary index used to list a user's keys
+const ApiKeyUserIndex = "UserId-index"
+
+var (
+	ErrApiKeyNotFound = errs.New(errs.NotFound, "API_KEY_NOT_FOUND", "api key not found")
+	ErrApiKeyRevoked  = errs.New(errs.NotFound, "API_KEY_NOT_FOUND", "api key not found or already revoked")
+)
+
+func (m *DynamoRepository) CreateApiKey(ctx context.Context, key model.ApiKey) (model.ApiKey, error) {
+	item, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return model.ApiKey{}, fmt.Errorf("could not marshal api key: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.ApiKeyTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(KeyId)"),
+	}); err != nil {
+		return model.ApiKey{}, dynamoError("putItem", err)
+	}
+
+	return key, nil
+}
+
+func (m *DynamoRepository) GetApiKey(ctx context.Context, keyId string) (model.ApiKey, error) {
+	var key model.ApiKey
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(m.App.ApiKeyTableName),
+		Key: map[string]types.AttributeValue{
+			"KeyId": &types.AttributeValueMemberS{Value: keyId},
+		},
+	})
+	if err != nil {
+		return key, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return key, ErrApiKeyNotFound
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Item, &key); err != nil {
+		return key, fmt.Errorf("could not unmarshal api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (m *DynamoRepository) ListApiKeys(ctx context.Context, userId string) ([]model.ApiKey, error) {
+	keys := []model.ApiKey{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.ApiKeyTableName),
+		IndexName:              aws.String(ApiKeyUserIndex),
+		KeyConditionExpression: aws.String("UserId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query api keys", err)
+		}
+
+		var page []model.ApiKey
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal api keys: %w", err)
+		}
+		keys = append(keys, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return keys, nil
+}
+
+func (m *DynamoRepository) RevokeApiKey(ctx context.Context, userId, keyId string) (model.ApiKey, error) {
+	var key model.ApiKey
+
+	revokedAt, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return key, fmt.Errorf("could not marshal revocation time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.ApiKeyTableName),
+		Key: map[string]types.AttributeValue{
+			"KeyId": &types.AttributeValueMemberS{Value: keyId},
+		},
+		UpdateExpression:    aws.String("SET RevokedAt = :revokedAt"),
+		ConditionExpression: aws.String("UserId = :userId AND attribute_not_exists(RevokedAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":revokedAt": revokedAt,
+			":userId":    &types.AttributeValueMemberS{Value: userId},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return key, ErrApiKeyRevoked
+		}
+		return key, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &key); err != nil {
+		return key, fmt.Errorf("could not unmarshal api key: %w", err)
+	}
+
+	return key, nil
+}
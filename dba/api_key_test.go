@@ -0,0 +1,115 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+)
+
+type ApiKeyDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	query *dynamodb.QueryInput
+}
+
+func (m *ApiKeyDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if params.Key["KeyId"].(*types.AttributeValueMemberS).Value != "0123456789abcdef" {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	return &dynamodb.GetItemOutput{
+		Item: map[string]types.AttributeValue{
+			"KeyId":      &types.AttributeValueMemberS{Value: "0123456789abcdef"},
+			"UserId":     &types.AttributeValueMemberS{Value: "123"},
+			"SecretHash": &types.AttributeValueMemberS{Value: "hash"},
+			"Scopes":     &types.AttributeValueMemberSS{Value: []string{"profile:read"}},
+		},
+	}, nil
+}
+
+func (m *ApiKeyDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *ApiKeyDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return nil, &types.ConditionalCheckFailedException{}
+}
+
+func (m *ApiKeyDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	m.query = params
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *ApiKeyDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *ApiKeyDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newApiKeyRepo(svc Database) *DynamoRepository {
+	return &DynamoRepository{
+		App: &config.AppConfig{ApiKeyTableName: "ApiKey"},
+		Svc: svc,
+	}
+}
+
+func TestGetApiKey(t *testing.T) {
+	repo := newApiKeyRepo(new(ApiKeyDynamoMock))
+
+	key, err := repo.GetApiKey(context.Background(), "0123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.SecretHash != "hash" || len(key.Scopes) != 1 {
+		t.Fatalf("unexpected api key: %+v", key)
+	}
+
+	if _, err = repo.GetApiKey(context.Background(), "missing"); !errors.Is(err, ErrApiKeyNotFound) {
+		t.Fatalf("expected ErrApiKeyNotFound, got %v", err)
+	}
+}
+
+func TestListApiKeysUsesUserIndex(t *testing.T) {
+	dynMock := new(ApiKeyDynamoMock)
+	repo := newApiKeyRepo(dynMock)
+
+	keys, err := repo.ListApiKeys(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys == nil || len(keys) != 0 {
+		t.Fatal("expected an empty list")
+	}
+	if dynMock.query.IndexName == nil || *dynMock.query.IndexName != ApiKeyUserIndex {
+		t.Fatal("expected keys to be listed through the user index")
+	}
+}
+
+func TestRevokeApiKeyOwnedByOtherUser(t *testing.T) {
+	repo := newApiKeyRepo(new(ApiKeyDynamoMock))
+
+	if _, err := repo.RevokeApiKey(context.Background(), "456", "0123456789abcdef"); !errors.Is(err, ErrApiKeyRevoked) {
+		t.Fatalf("expected ErrApiKeyRevoked, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// RecordAttributeSync stores a failed attribute update, replacing any earlier
+// one pending for the same user
+func (m *DynamoRepository) RecordAttributeSync(ctx context.Context, sync model.AttributeSync) (model.AttributeSync, error) {
+	sync.FailedAt = sync.FailedAt.UTC()
+
+	item, err := attributevalue.MarshalMap(sync)
+	if err != nil {
+		return model.AttributeSync{}, fmt.Errorf("could not marshal attribute sync: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(m.App.AttributeSyncTableName),
+		Item:      item,
+	}); err != nil {
+		return model.AttributeSync{}, dynamoError("putItem", err)
+	}
+
+	return sync, nil
+}
+
+func (m *DynamoRepository) ListAttributeSyncs(ctx context.Context, provider string) ([]model.AttributeSync, error) {
+	syncs := []model.AttributeSync{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.AttributeSyncTableName),
+		KeyConditionExpression: aws.String("Provider = :provider"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":provider": &types.AttributeValueMemberS{Value: provider},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query attribute syncs", err)
+		}
+
+		var page []model.AttributeSync
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal attribute syncs: %w", err)
+		}
+		syncs = append(syncs, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return syncs, nil
+}
+
+// DeleteAttributeSync drops the pending update of a user, if there is one
+func (m *DynamoRepository) DeleteAttributeSync(ctx context.Context, provider, userId string) error {
+	if _, err := m.Svc.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(m.App.AttributeSyncTableName),
+		Key: map[string]types.AttributeValue{
+			"Provider": &types.AttributeValueMemberS{Value: provider},
+			"UserId":   &types.AttributeValueMemberS{Value: userId},
+		},
+	}); err != nil {
+		return dynamoError("deleteItem", err)
+	}
+	return nil
+}
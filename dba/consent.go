@@ -0,0 +1,126 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/google/uuid"
+)
+
+var ErrConsentNotFound = errs.New(errs.NotFound, "CONSENT_NOT_FOUND", "consent not found or already withdrawn")
+
+func (m *DynamoRepository) RecordConsent(ctx context.Context, consent model.Consent) (model.Consent, error) {
+	consent.ConsentId = uuid.New().String()
+	if consent.AcceptedAt.IsZero() {
+		consent.AcceptedAt = time.Now().UTC()
+	}
+	consent.WithdrawnAt = nil
+
+	item, err := attributevalue.MarshalMap(consent)
+	if err != nil {
+		return model.Consent{}, fmt.Errorf("could not marshal consent: %w", err)
+	}
+
+	// the ledger is append only, never overwrite an existing record
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.ConsentTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(ConsentId)"),
+	}); err != nil {
+		return model.Consent{}, dynamoError("putItem", err)
+	}
+
+	return consent, nil
+}
+
+func (m *DynamoRepository) ListConsents(ctx context.Context, userId string) ([]model.Consent, error) {
+	consents := []model.Consent{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.ConsentTableName),
+		KeyConditionExpression: aws.String("UserId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query consents", err)
+		}
+
+		var page []model.Consent
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal consents: %w", err)
+		}
+		consents = append(consents, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return consents, nil
+}
+
+func (m *DynamoRepository) WithdrawConsent(ctx context.Context, userId, consentId string) (model.Consent, error) {
+	var consent model.Consent
+
+	withdrawnAt, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return consent, fmt.Errorf("could not marshal withdrawal time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.ConsentTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId":    &types.AttributeValueMemberS{Value: userId},
+			"ConsentId": &types.AttributeValueMemberS{Value: consentId},
+		},
+		UpdateExpression:    aws.String("SET WithdrawnAt = :withdrawnAt"),
+		ConditionExpression: aws.String("attribute_exists(ConsentId) AND attribute_not_exists(WithdrawnAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":withdrawnAt": withdrawnAt,
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return consent, ErrConsentNotFound
+		}
+		return consent, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &consent); err != nil {
+		return consent, fmt.Errorf("could not unmarshal consent: %w", err)
+	}
+
+	return consent, nil
+}
@@ -0,0 +1,159 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+type ConsentDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	put       *dynamodb.PutItemInput
+	pages     [][]map[string]types.AttributeValue
+	queries   int
+	withdrawn bool
+}
+
+func (m *ConsentDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (m *ConsentDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	m.put = params
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *ConsentDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	if m.withdrawn {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.withdrawn = true
+	return &dynamodb.UpdateItemOutput{
+		Attributes: map[string]types.AttributeValue{
+			"UserId":      params.Key["UserId"],
+			"ConsentId":   params.Key["ConsentId"],
+			"WithdrawnAt": params.ExpressionAttributeValues[":withdrawnAt"],
+		},
+	}, nil
+}
+
+func (m *ConsentDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	page := m.pages[m.queries]
+	m.queries++
+	out := &dynamodb.QueryOutput{Items: page}
+	if m.queries < len(m.pages) {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{
+			"UserId": &types.AttributeValueMemberS{Value: "123"},
+		}
+	}
+	return out, nil
+}
+
+func (m *ConsentDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *ConsentDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newConsentRepo(svc Database) *DynamoRepository {
+	return &DynamoRepository{
+		App: &config.AppConfig{ConsentTableName: "Consent"},
+		Svc: svc,
+	}
+}
+
+func TestRecordConsent(t *testing.T) {
+	dynMock := new(ConsentDynamoMock)
+	repo := newConsentRepo(dynMock)
+
+	consent, err := repo.RecordConsent(context.Background(), model.Consent{
+		UserId:          "123",
+		DocumentType:    "tos",
+		DocumentVersion: "2023-01",
+		IpAddress:       "10.0.0.1",
+		UserAgent:       "test-agent",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(consent.ConsentId) != 36 {
+		t.Fatal("expected a generated consent id")
+	}
+	if consent.AcceptedAt.IsZero() {
+		t.Fatal("expected accepted at to be set")
+	}
+
+	var stored model.Consent
+	if err := attributevalue.UnmarshalMap(dynMock.put.Item, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.DocumentVersion != "2023-01" || stored.IpAddress != "10.0.0.1" || stored.UserAgent != "test-agent" {
+		t.Fatalf("unexpected stored consent: %+v", stored)
+	}
+	if dynMock.put.ConditionExpression == nil {
+		t.Fatal("expected consent records to never be overwritten")
+	}
+}
+
+func TestListConsentsPaginates(t *testing.T) {
+	dynMock := &ConsentDynamoMock{
+		pages: [][]map[string]types.AttributeValue{
+			{{"ConsentId": &types.AttributeValueMemberS{Value: "a"}}},
+			{{"ConsentId": &types.AttributeValueMemberS{Value: "b"}}},
+		},
+	}
+	repo := newConsentRepo(dynMock)
+
+	consents, err := repo.ListConsents(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(consents) != 2 || consents[1].ConsentId != "b" {
+		t.Fatalf("expected both pages of consents, got %+v", consents)
+	}
+}
+
+func TestWithdrawConsent(t *testing.T) {
+	dynMock := new(ConsentDynamoMock)
+	repo := newConsentRepo(dynMock)
+
+	consent, err := repo.WithdrawConsent(context.Background(), "123", "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if consent.IsActive() {
+		t.Fatal("expected consent to be withdrawn")
+	}
+
+	_, err = repo.WithdrawConsent(context.Background(), "123", "abc")
+	if !errors.Is(err, ErrConsentNotFound) {
+		t.Fatalf("expected ErrConsentNotFound, got %v", err)
+	}
+}
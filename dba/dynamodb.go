@@ -18,6 +18,8 @@ package dba
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	awsConfig "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -26,22 +28,107 @@ import (
 )
 
 type Repository interface {
-	ReadProfile(id string) (model.ProfileResponse, error)
-	UpdateProfile(id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error)
+	ReadProfile(ctx context.Context, id string) (model.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error)
+	CreateProfile(ctx context.Context, id string, createBody model.UpdateProfileRequest) (model.ProfileResponse, error)
+	ListProfiles(ctx context.Context) ([]model.ProfileResponse, error)
+	DeleteProfile(ctx context.Context, id string) error
+	MergeProfiles(ctx context.Context, survivorId, loserId string, merged model.UpdateProfileRequest) (model.ProfileResponse, error)
+}
+
+// ProfileStore hands out profile repositories confined to one organization,
+// it is backed by DynamoDB or PostgreSQL depending on PROFILE_STORE
+type ProfileStore interface {
+	ForOrg(orgId string) Repository
+}
+
+type ConsentRepository interface {
+	RecordConsent(ctx context.Context, consent model.Consent) (model.Consent, error)
+	ListConsents(ctx context.Context, userId string) ([]model.Consent, error)
+	WithdrawConsent(ctx context.Context, userId, consentId string) (model.Consent, error)
+}
+
+type SessionRepository interface {
+	TouchSession(ctx context.Context, session model.Session) (model.Session, error)
+	GetSession(ctx context.Context, userId, sessionId string) (model.Session, error)
+	ListSessions(ctx context.Context, userId string) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userId, sessionId string) (model.Session, error)
+	RevokeOtherSessions(ctx context.Context, userId, currentSessionId string) ([]model.Session, error)
+}
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, key model.ApiKey) (model.ApiKey, error)
+	GetApiKey(ctx context.Context, keyId string) (model.ApiKey, error)
+	ListApiKeys(ctx context.Context, userId string) ([]model.ApiKey, error)
+	RevokeApiKey(ctx context.Context, userId, keyId string) (model.ApiKey, error)
+}
+
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, invitation model.Invitation) (model.Invitation, error)
+	GetInvitation(ctx context.Context, invitationId string) (model.Invitation, error)
+	ClaimInvitation(ctx context.Context, invitationId, userId string) (model.Invitation, error)
+	ReleaseInvitation(ctx context.Context, invitationId string) error
+}
+
+type MembershipRepository interface {
+	GetMembership(ctx context.Context, userId string) (model.Membership, error)
+	AddMembership(ctx context.Context, membership model.Membership) (model.Membership, error)
+	MoveMembership(ctx context.Context, fromOrgId string, membership model.Membership) (model.Membership, error)
+	RemoveMembership(ctx context.Context, userId, orgId string) error
+}
+
+type ImpersonationRepository interface {
+	CreateImpersonationGrant(ctx context.Context, grant model.ImpersonationGrant) (model.ImpersonationGrant, error)
+	GetImpersonationGrant(ctx context.Context, grantId string) (model.ImpersonationGrant, error)
+	ListImpersonationGrants(ctx context.Context, actorId string) ([]model.ImpersonationGrant, error)
+	RevokeImpersonationGrant(ctx context.Context, actorId, grantId string) (model.ImpersonationGrant, error)
+}
+
+type HistoryRepository interface {
+	RecordHistory(ctx context.Context, event model.HistoryEvent) (model.HistoryEvent, error)
+}
+
+type RevocationRepository interface {
+	RecordRevocation(ctx context.Context, revocation model.Revocation) (model.Revocation, error)
+	ListRevocations(ctx context.Context, userId string) ([]model.Revocation, error)
+}
+
+type AttributeSyncRepository interface {
+	RecordAttributeSync(ctx context.Context, sync model.AttributeSync) (model.AttributeSync, error)
+	ListAttributeSyncs(ctx context.Context, provider string) ([]model.AttributeSync, error)
+	DeleteAttributeSync(ctx context.Context, provider, userId string) error
 }
 
 type Database interface {
 	GetItem(ctx context.Context, getItemInput *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
 	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 }
 
 // Repo the repository used by dynamo
 var Repo *DynamoRepository
 
+// Profiles the store profile calls go through, Repo unless another store was
+// set with NewProfileDBA
+var Profiles ProfileStore
+
 // Repository is the repository type
 type DynamoRepository struct {
 	App *config.AppConfig
 	Svc Database
+	// OrgId confines every profile call to a single organization, see ForOrg
+	OrgId string
+}
+
+// ForOrg returns a copy of the repository whose profile calls can only read
+// and write rows belonging to orgId
+func (m *DynamoRepository) ForOrg(orgId string) Repository {
+	scoped := *m
+	scoped.OrgId = orgId
+	return &scoped
 }
 
 // NewRepo creates a new repository
@@ -50,13 +137,85 @@ func NewRepo(a *config.AppConfig, cfg awsConfig.Config) *DynamoRepository {
 
 	return &DynamoRepository{
 		App: a,
-		Svc: svc,
+		Svc: withTimeouts(svc, a.DbTimeout, a.GetDbTimeouts()),
 	}
 }
 
 // NewDBA sets the repository for the handlers
 func NewDBA(r *DynamoRepository) {
 	Repo = r
+	Profiles = r
+}
+
+// NewProfileDBA sets the profile store for the handlers
+func NewProfileDBA(s ProfileStore) {
+	Profiles = s
+}
+
+// NewProfileStore returns the profile store PROFILE_STORE selects, which is
+// repo itself for DynamoDB
+func NewProfileStore(ctx context.Context, a *config.AppConfig, repo *DynamoRepository) (ProfileStore, error) {
+	switch a.ProfileStore {
+	case config.ProfileStoreDynamo:
+		return repo, nil
+	case config.ProfileStorePostgres:
+		pg, err := NewPostgresRepo(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		return pg, nil
+	}
+	return nil, fmt.Errorf("unknown profile store: %q", a.ProfileStore)
+}
+
+// tableDescriber is the part of the DynamoDB client CheckTables needs
+type tableDescriber interface {
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+}
+
+// CheckTables fails when a DynamoDB table the service uses cannot be
+// described, so a missing table or endpoint stops the server at startup
+// rather than failing requests. Only the profile table moves with
+// PROFILE_STORE, every other table stays in DynamoDB.
+func CheckTables(ctx context.Context, a *config.AppConfig, cfg awsConfig.Config) error {
+	return checkTables(ctx, a, setupService(a, cfg))
+}
+
+func checkTables(ctx context.Context, a *config.AppConfig, svc tableDescriber) error {
+	tables := []string{
+		a.ConsentTableName,
+		a.SessionTableName,
+		a.ApiKeyTableName,
+		a.InvitationTableName,
+		a.MembershipTableName,
+		a.ImpersonationTableName,
+		a.HistoryTableName,
+		a.AttributeSyncTableName,
+		a.RevocationTableName,
+	}
+	if a.ProfileStore == config.ProfileStoreDynamo {
+		tables = append(tables, a.ProfileTableName)
+	}
+
+	for _, table := range tables {
+		if err := describeTable(ctx, svc, table, a.DbTimeout); err != nil {
+			if a.ProfileStore != config.ProfileStoreDynamo {
+				return fmt.Errorf("dynamodb table %s is not reachable, only profiles move to %s: %w", table, a.ProfileStore, err)
+			}
+			return fmt.Errorf("dynamodb table %s is not reachable: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func describeTable(ctx context.Context, svc tableDescriber, table string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	_, err := svc.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: awsConfig.String(table)})
+	return err
 }
 
 func setupService(app *config.AppConfig, cfg awsConfig.Config) (svc *dynamodb.Client) {
@@ -18,9 +18,13 @@ package dba
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
 )
 
@@ -86,3 +90,49 @@ func TestNewDBA(t *testing.T) {
 		t.Fatal("service expected nil")
 	}
 }
+
+// fakeTables describes the tables it holds, like DescribeTable it fails with
+// ResourceNotFoundException for any other
+type fakeTables map[string]bool
+
+func (f fakeTables) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	if !f[aws.ToString(params.TableName)] {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}
+	}
+	return &dynamodb.DescribeTableOutput{}, nil
+}
+
+func TestCheckTables(t *testing.T) {
+	app := &config.AppConfig{
+		ProfileStore:           config.ProfileStorePostgres,
+		ProfileTableName:       "Profile",
+		ConsentTableName:       "Consent",
+		SessionTableName:       "Session",
+		ApiKeyTableName:        "ApiKey",
+		InvitationTableName:    "Invitation",
+		MembershipTableName:    "Membership",
+		ImpersonationTableName: "Impersonation",
+		HistoryTableName:       "History",
+		AttributeSyncTableName: "AttributeSync",
+		RevocationTableName:    "Revocation",
+	}
+	tables := fakeTables{"Consent": true, "Session": true, "ApiKey": true, "Invitation": true, "Membership": true,
+		"Impersonation": true, "History": true, "AttributeSync": true, "Revocation": true}
+
+	// profiles live in postgres, so their table is not needed
+	if err := checkTables(context.Background(), app, tables); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	app.ProfileStore = config.ProfileStoreDynamo
+	if err := checkTables(context.Background(), app, tables); err == nil || !strings.Contains(err.Error(), "Profile") {
+		t.Fatalf("expected the missing profile table to be named, got %v", err)
+	}
+
+	app.ProfileStore = config.ProfileStorePostgres
+	delete(tables, "Session")
+	err := checkTables(context.Background(), app, tables)
+	if err == nil || !strings.Contains(err.Error(), "Session") || !strings.Contains(err.Error(), "postgres") {
+		t.Fatalf("expected the missing session table to be named, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+)
+
+// ErrThrottled is returned when DynamoDB sheds load, clients should retry
+var ErrThrottled = errs.New(errs.Throttled, "BACKEND_THROTTLED", "the service is busy, retry later")
+
+// apiError is the smithy error interface every DynamoDB service error implements
+type apiError interface {
+	ErrorCode() string
+}
+
+var throttlingCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"RequestLimitExceeded":                   true,
+	"ThrottlingException":                    true,
+}
+
+// dynamoError describes a failed DynamoDB call, classifying throttling so it
+// reaches clients as retryable instead of as an internal error
+func dynamoError(op string, err error) error {
+	wrapped := fmt.Errorf("dynamodb could not %s: %w", op, err)
+
+	var apiErr apiError
+	if errors.As(err, &apiErr) && throttlingCodes[apiErr.ErrorCode()] {
+		return ErrThrottled.Wrap(wrapped)
+	}
+	return wrapped
+}
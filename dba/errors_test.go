@@ -0,0 +1,37 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestDynamoErrorClassifiesThrottling(t *testing.T) {
+	err := dynamoError("getItem", &types.ProvisionedThroughputExceededException{Message: aws.String("slow down")})
+	if !errors.Is(err, ErrThrottled) {
+		t.Fatalf("expected throttling to be classified, got %v", err)
+	}
+
+	err = dynamoError("getItem", errors.New("connection reset"))
+	if errors.Is(err, ErrThrottled) || err.Error() != "dynamodb could not getItem: connection reset" {
+		t.Fatalf("expected other errors to be wrapped as before, got %v", err)
+	}
+}
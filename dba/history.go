@@ -0,0 +1,57 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"github.com/google/uuid"
+)
+
+// historyTimeLayout is fixed width, unlike RFC3339Nano, so event ids sort
+// lexically in the order they occurred
+const historyTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// RecordHistory appends an event to the user's audit trail. Event ids start
+// with the time the event occurred so the trail sorts chronologically.
+func (m *DynamoRepository) RecordHistory(ctx context.Context, event model.HistoryEvent) (model.HistoryEvent, error) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	event.OccurredAt = event.OccurredAt.UTC()
+	event.EventId = event.OccurredAt.Format(historyTimeLayout) + "#" + uuid.New().String()
+
+	item, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		return model.HistoryEvent{}, fmt.Errorf("could not marshal history event: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(m.App.HistoryTableName),
+		Item:      item,
+	}); err != nil {
+		return model.HistoryEvent{}, dynamoError("putItem", err)
+	}
+
+	return event, nil
+}
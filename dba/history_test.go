@@ -0,0 +1,47 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+func TestRecordHistoryEventIdsSortChronologically(t *testing.T) {
+	repo := &DynamoRepository{
+		App: &config.AppConfig{HistoryTableName: "History"},
+		Svc: new(ImpersonationDynamoMock),
+	}
+
+	base := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	first, err := repo.RecordHistory(context.Background(), model.HistoryEvent{UserId: "123", OccurredAt: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.RecordHistory(context.Background(), model.HistoryEvent{UserId: "123", OccurredAt: base.Add(100 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.EventId >= second.EventId {
+		t.Fatalf("expected %s to sort before %s", first.EventId, second.EventId)
+	}
+}
@@ -0,0 +1,150 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// ImpersonationActorIndex is the global secondary index used to list the
+// grants a support engineer has issued
+const ImpersonationActorIndex = "ActorId-index"
+
+var (
+	ErrImpersonationGrantNotFound = errs.New(errs.NotFound, "IMPERSONATION_GRANT_NOT_FOUND", "impersonation grant not found")
+	ErrImpersonationGrantRevoked  = errs.New(errs.NotFound, "IMPERSONATION_GRANT_NOT_FOUND", "impersonation grant not found or already revoked")
+)
+
+func (m *DynamoRepository) CreateImpersonationGrant(ctx context.Context, grant model.ImpersonationGrant) (model.ImpersonationGrant, error) {
+	item, err := attributevalue.MarshalMap(grant)
+	if err != nil {
+		return model.ImpersonationGrant{}, fmt.Errorf("could not marshal impersonation grant: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.ImpersonationTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(GrantId)"),
+	}); err != nil {
+		return model.ImpersonationGrant{}, dynamoError("putItem", err)
+	}
+
+	return grant, nil
+}
+
+func (m *DynamoRepository) GetImpersonationGrant(ctx context.Context, grantId string) (model.ImpersonationGrant, error) {
+	var grant model.ImpersonationGrant
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(m.App.ImpersonationTableName),
+		Key: map[string]types.AttributeValue{
+			"GrantId": &types.AttributeValueMemberS{Value: grantId},
+		},
+	})
+	if err != nil {
+		return grant, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return grant, ErrImpersonationGrantNotFound
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Item, &grant); err != nil {
+		return grant, fmt.Errorf("could not unmarshal impersonation grant: %w", err)
+	}
+
+	return grant, nil
+}
+
+func (m *DynamoRepository) ListImpersonationGrants(ctx context.Context, actorId string) ([]model.ImpersonationGrant, error) {
+	grants := []model.ImpersonationGrant{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.ImpersonationTableName),
+		IndexName:              aws.String(ImpersonationActorIndex),
+		KeyConditionExpression: aws.String("ActorId = :actorId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":actorId": &types.AttributeValueMemberS{Value: actorId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query impersonation grants", err)
+		}
+
+		var page []model.ImpersonationGrant
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal impersonation grants: %w", err)
+		}
+		grants = append(grants, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return grants, nil
+}
+
+func (m *DynamoRepository) RevokeImpersonationGrant(ctx context.Context, actorId, grantId string) (model.ImpersonationGrant, error) {
+	var grant model.ImpersonationGrant
+
+	revokedAt, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return grant, fmt.Errorf("could not marshal revocation time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.ImpersonationTableName),
+		Key: map[string]types.AttributeValue{
+			"GrantId": &types.AttributeValueMemberS{Value: grantId},
+		},
+		UpdateExpression:    aws.String("SET RevokedAt = :revokedAt"),
+		ConditionExpression: aws.String("ActorId = :actorId AND attribute_not_exists(RevokedAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":revokedAt": revokedAt,
+			":actorId":   &types.AttributeValueMemberS{Value: actorId},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return grant, ErrImpersonationGrantRevoked
+		}
+		return grant, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &grant); err != nil {
+		return grant, fmt.Errorf("could not unmarshal impersonation grant: %w", err)
+	}
+
+	return grant, nil
+}
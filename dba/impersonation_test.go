@@ -0,0 +1,108 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+type ImpersonationDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	put   *dynamodb.PutItemInput
+	query *dynamodb.QueryInput
+}
+
+func (m *ImpersonationDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	m.put = params
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return nil, &types.ConditionalCheckFailedException{}
+}
+
+func (m *ImpersonationDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	m.query = params
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newImpersonationRepo(svc Database) *DynamoRepository {
+	return &DynamoRepository{
+		App: &config.AppConfig{ImpersonationTableName: "Impersonation"},
+		Svc: svc,
+	}
+}
+
+func TestCreateImpersonationGrantSetsTtl(t *testing.T) {
+	dynMock := new(ImpersonationDynamoMock)
+	repo := newImpersonationRepo(dynMock)
+
+	if _, err := repo.CreateImpersonationGrant(context.Background(), model.ImpersonationGrant{GrantId: "grant-1", Ttl: 1700000000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ttl, ok := dynMock.put.Item["Ttl"].(*types.AttributeValueMemberN); !ok || ttl.Value != "1700000000" {
+		t.Fatalf("expected a numeric ttl attribute, got %v", dynMock.put.Item["Ttl"])
+	}
+}
+
+func TestGetImpersonationGrantNotFound(t *testing.T) {
+	repo := newImpersonationRepo(new(ImpersonationDynamoMock))
+
+	if _, err := repo.GetImpersonationGrant(context.Background(), "missing"); !errors.Is(err, ErrImpersonationGrantNotFound) {
+		t.Fatalf("expected ErrImpersonationGrantNotFound, got %v", err)
+	}
+}
+
+func TestListImpersonationGrantsUsesActorIndex(t *testing.T) {
+	dynMock := new(ImpersonationDynamoMock)
+	repo := newImpersonationRepo(dynMock)
+
+	if _, err := repo.ListImpersonationGrants(context.Background(), "support-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dynMock.query.IndexName == nil || *dynMock.query.IndexName != ImpersonationActorIndex {
+		t.Fatal("expected grants to be listed through the actor index")
+	}
+}
+
+func TestRevokeImpersonationGrantOwnedByOtherActor(t *testing.T) {
+	repo := newImpersonationRepo(new(ImpersonationDynamoMock))
+
+	if _, err := repo.RevokeImpersonationGrant(context.Background(), "support-2", "grant-1"); !errors.Is(err, ErrImpersonationGrantRevoked) {
+		t.Fatalf("expected ErrImpersonationGrantRevoked, got %v", err)
+	}
+}
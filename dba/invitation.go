@@ -0,0 +1,132 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+var (
+	ErrInvitationNotFound = errs.New(errs.NotFound, "INVITATION_NOT_FOUND", "invitation not found")
+	ErrInvitationClaimed  = errs.New(errs.FailedPrecondition, "INVITATION_CLAIMED", "invitation already accepted or expired")
+)
+
+func (m *DynamoRepository) CreateInvitation(ctx context.Context, invitation model.Invitation) (model.Invitation, error) {
+	item, err := attributevalue.MarshalMap(invitation)
+	if err != nil {
+		return model.Invitation{}, fmt.Errorf("could not marshal invitation: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.InvitationTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(InvitationId)"),
+	}); err != nil {
+		return model.Invitation{}, dynamoError("putItem", err)
+	}
+
+	return invitation, nil
+}
+
+func (m *DynamoRepository) GetInvitation(ctx context.Context, invitationId string) (model.Invitation, error) {
+	var invitation model.Invitation
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(m.App.InvitationTableName),
+		Key: map[string]types.AttributeValue{
+			"InvitationId": &types.AttributeValueMemberS{Value: invitationId},
+		},
+	})
+	if err != nil {
+		return invitation, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return invitation, ErrInvitationNotFound
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Item, &invitation); err != nil {
+		return invitation, fmt.Errorf("could not unmarshal invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ClaimInvitation marks the invitation as accepted by userId. The write only
+// succeeds once and only before the invitation expires, which is what makes
+// the token single use.
+func (m *DynamoRepository) ClaimInvitation(ctx context.Context, invitationId, userId string) (model.Invitation, error) {
+	var invitation model.Invitation
+
+	now, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return invitation, fmt.Errorf("could not marshal acceptance time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.InvitationTableName),
+		Key: map[string]types.AttributeValue{
+			"InvitationId": &types.AttributeValueMemberS{Value: invitationId},
+		},
+		UpdateExpression:    aws.String("SET AcceptedAt = :now, AcceptedBy = :userId"),
+		ConditionExpression: aws.String("attribute_exists(InvitationId) AND attribute_not_exists(AcceptedAt) AND ExpiresAt > :now"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now":    now,
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return invitation, ErrInvitationClaimed
+		}
+		return invitation, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &invitation); err != nil {
+		return invitation, fmt.Errorf("could not unmarshal invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ReleaseInvitation undoes a claim so the invitation can be accepted again,
+// used when the profile could not be created after claiming
+func (m *DynamoRepository) ReleaseInvitation(ctx context.Context, invitationId string) error {
+	if _, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.InvitationTableName),
+		Key: map[string]types.AttributeValue{
+			"InvitationId": &types.AttributeValueMemberS{Value: invitationId},
+		},
+		UpdateExpression: aws.String("REMOVE AcceptedAt, AcceptedBy"),
+	}); err != nil {
+		return dynamoError("updateItem", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+)
+
+type InvitationDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	claimed bool
+	update  *dynamodb.UpdateItemInput
+}
+
+func (m *InvitationDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (m *InvitationDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *InvitationDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	m.update = params
+	if params.ConditionExpression != nil && m.claimed {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.claimed = params.ConditionExpression != nil
+	return &dynamodb.UpdateItemOutput{
+		Attributes: map[string]types.AttributeValue{
+			"InvitationId": params.Key["InvitationId"],
+			"AcceptedBy":   &types.AttributeValueMemberS{Value: "123"},
+		},
+	}, nil
+}
+
+func (m *InvitationDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *InvitationDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *InvitationDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newInvitationRepo(svc Database) *DynamoRepository {
+	return &DynamoRepository{
+		App: &config.AppConfig{InvitationTableName: "Invitation"},
+		Svc: svc,
+	}
+}
+
+func TestGetInvitationNotFound(t *testing.T) {
+	repo := newInvitationRepo(new(InvitationDynamoMock))
+
+	if _, err := repo.GetInvitation(context.Background(), "missing"); !errors.Is(err, ErrInvitationNotFound) {
+		t.Fatalf("expected ErrInvitationNotFound, got %v", err)
+	}
+}
+
+func TestClaimInvitationIsSingleUse(t *testing.T) {
+	dynMock := new(InvitationDynamoMock)
+	repo := newInvitationRepo(dynMock)
+
+	invitation, err := repo.ClaimInvitation(context.Background(), "inv-1", "123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invitation.AcceptedBy != "123" {
+		t.Fatalf("unexpected invitation: %+v", invitation)
+	}
+	if !strings.Contains(*dynMock.update.ConditionExpression, "attribute_not_exists(AcceptedAt)") {
+		t.Fatal("expected the claim to be conditional on the invitation being unclaimed")
+	}
+
+	if _, err = repo.ClaimInvitation(context.Background(), "inv-1", "456"); !errors.Is(err, ErrInvitationClaimed) {
+		t.Fatalf("expected ErrInvitationClaimed, got %v", err)
+	}
+
+	if err = repo.ReleaseInvitation(context.Background(), "inv-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = repo.ClaimInvitation(context.Background(), "inv-1", "456"); err != nil {
+		t.Fatalf("expected a released invitation to be claimable, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+var (
+	ErrMembershipNotFound = errs.New(errs.NotFound, "MEMBERSHIP_NOT_FOUND", "user is not a member of the organization")
+	ErrMembershipConflict = errs.New(errs.FailedPrecondition, "MEMBERSHIP_CONFLICT", "user already belongs to another organization")
+)
+
+// GetMembership returns the organization userId belongs to. A user who
+// belongs to none gets a membership with an empty OrgId.
+func (m *DynamoRepository) GetMembership(ctx context.Context, userId string) (model.Membership, error) {
+	membership := model.Membership{UserId: userId}
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(m.App.MembershipTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return membership, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return membership, nil
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Item, &membership); err != nil {
+		return membership, fmt.Errorf("could not unmarshal membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// AddMembership records the user as a member of the organization. Joining
+// the same organization again succeeds, joining another one fails with
+// ErrMembershipConflict.
+func (m *DynamoRepository) AddMembership(ctx context.Context, membership model.Membership) (model.Membership, error) {
+	item, err := attributevalue.MarshalMap(membership)
+	if err != nil {
+		return membership, fmt.Errorf("could not marshal membership: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.MembershipTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(UserId) OR OrgId = :orgId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":orgId": &types.AttributeValueMemberS{Value: membership.OrgId},
+		},
+	}); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return membership, ErrMembershipConflict
+		}
+		return membership, dynamoError("putItem", err)
+	}
+
+	return membership, nil
+}
+
+// MoveMembership moves the user from fromOrgId to the organization of
+// membership, failing with ErrMembershipConflict when they are not a member of
+// fromOrgId anymore
+func (m *DynamoRepository) MoveMembership(ctx context.Context, fromOrgId string, membership model.Membership) (model.Membership, error) {
+	item, err := attributevalue.MarshalMap(membership)
+	if err != nil {
+		return membership, fmt.Errorf("could not marshal membership: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.MembershipTableName),
+		Item:                item,
+		ConditionExpression: aws.String("OrgId = :fromOrgId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":fromOrgId": &types.AttributeValueMemberS{Value: fromOrgId},
+		},
+	}); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return membership, ErrMembershipConflict
+		}
+		return membership, dynamoError("putItem", err)
+	}
+
+	return membership, nil
+}
+
+// RemoveMembership ends the user's membership of orgId, failing with
+// ErrMembershipNotFound when they are not a member of it
+func (m *DynamoRepository) RemoveMembership(ctx context.Context, userId, orgId string) error {
+	if _, err := m.Svc.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(m.App.MembershipTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId": &types.AttributeValueMemberS{Value: userId},
+		},
+		ConditionExpression: aws.String("OrgId = :orgId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":orgId": &types.AttributeValueMemberS{Value: orgId},
+		},
+	}); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrMembershipNotFound
+		}
+		return dynamoError("deleteItem", err)
+	}
+
+	return nil
+}
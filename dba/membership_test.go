@@ -0,0 +1,151 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// MembershipDynamoMock keeps memberships by user, honoring the org conditions
+// of AddMembership, MoveMembership and RemoveMembership
+type MembershipDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	items map[string]map[string]types.AttributeValue
+}
+
+func orgOf(item map[string]types.AttributeValue) string {
+	if v, ok := item["OrgId"].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
+
+func userOf(item map[string]types.AttributeValue) string {
+	return item["UserId"].(*types.AttributeValueMemberS).Value
+}
+
+func (m *MembershipDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: m.items[userOf(params.Key)]}, nil
+}
+
+func (m *MembershipDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	existing, ok := m.items[userOf(params.Item)]
+	if from, moving := params.ExpressionAttributeValues[":fromOrgId"].(*types.AttributeValueMemberS); moving {
+		if !ok || orgOf(existing) != from.Value {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+	} else if ok && orgOf(existing) != orgOf(params.Item) {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.items[userOf(params.Item)] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *MembershipDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (m *MembershipDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *MembershipDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	existing, ok := m.items[userOf(params.Key)]
+	if !ok || orgOf(existing) != params.ExpressionAttributeValues[":orgId"].(*types.AttributeValueMemberS).Value {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	delete(m.items, userOf(params.Key))
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *MembershipDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func TestMembershipBelongsToOneOrg(t *testing.T) {
+	repo := &DynamoRepository{
+		App: &config.AppConfig{MembershipTableName: "Membership"},
+		Svc: &MembershipDynamoMock{items: map[string]map[string]types.AttributeValue{}},
+	}
+	ctx := context.Background()
+
+	membership, err := repo.GetMembership(ctx, "123")
+	if err != nil || membership.OrgId != "" {
+		t.Fatalf("expected no membership, got %+v, %v", membership, err)
+	}
+
+	if _, err = repo.AddMembership(ctx, model.Membership{UserId: "123", OrgId: "org-1", JoinedAt: time.Now()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = repo.AddMembership(ctx, model.Membership{UserId: "123", OrgId: "org-1", JoinedAt: time.Now()}); err != nil {
+		t.Fatalf("expected joining the same org again to succeed, got %v", err)
+	}
+	if _, err = repo.AddMembership(ctx, model.Membership{UserId: "123", OrgId: "org-2"}); !errors.Is(err, ErrMembershipConflict) {
+		t.Fatalf("expected ErrMembershipConflict, got %v", err)
+	}
+
+	membership, err = repo.GetMembership(ctx, "123")
+	if err != nil || membership.OrgId != "org-1" {
+		t.Fatalf("expected a membership of org-1, got %+v, %v", membership, err)
+	}
+
+	if err = repo.RemoveMembership(ctx, "123", "org-2"); !errors.Is(err, ErrMembershipNotFound) {
+		t.Fatalf("expected ErrMembershipNotFound, got %v", err)
+	}
+	if err = repo.RemoveMembership(ctx, "123", "org-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if membership, _ = repo.GetMembership(ctx, "123"); membership.OrgId != "" {
+		t.Fatalf("expected the membership to be gone, got %+v", membership)
+	}
+}
+
+func TestMoveMembership(t *testing.T) {
+	repo := &DynamoRepository{
+		App: &config.AppConfig{MembershipTableName: "Membership"},
+		Svc: &MembershipDynamoMock{items: map[string]map[string]types.AttributeValue{}},
+	}
+	ctx := context.Background()
+	pending := model.PendingOrgId("123")
+
+	if _, err := repo.MoveMembership(ctx, pending, model.Membership{UserId: "123", OrgId: "org-1"}); !errors.Is(err, ErrMembershipConflict) {
+		t.Fatalf("expected ErrMembershipConflict without a membership, got %v", err)
+	}
+	if _, err := repo.AddMembership(ctx, model.Membership{UserId: "123", OrgId: pending}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.MoveMembership(ctx, pending, model.Membership{UserId: "123", OrgId: "org-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.MoveMembership(ctx, pending, model.Membership{UserId: "123", OrgId: "org-2"}); !errors.Is(err, ErrMembershipConflict) {
+		t.Fatalf("expected a second move to fail with ErrMembershipConflict, got %v", err)
+	}
+
+	membership, err := repo.GetMembership(ctx, "123")
+	if err != nil || membership.OrgId != "org-1" {
+		t.Fatalf("expected a membership of org-1, got %+v, %v", membership, err)
+	}
+}
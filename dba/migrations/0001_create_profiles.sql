@@ -0,0 +1,18 @@
+-- profiles mirrors the DynamoDB Profile table, keyed by organization first so
+-- every query stays inside one organization
+CREATE TABLE profiles (
+    org_id        text        NOT NULL,
+    user_id       text        NOT NULL,
+    email         text        NOT NULL DEFAULT '',
+    name          text        NOT NULL DEFAULT '',
+    legal_name    text        NOT NULL DEFAULT '',
+    user_name     text        NOT NULL DEFAULT '',
+    roles         jsonb       NOT NULL DEFAULT '[]',
+    address       text        NOT NULL DEFAULT '',
+    date_of_birth text        NOT NULL DEFAULT '',
+    created_at    timestamptz NOT NULL,
+    updated_at    timestamptz NOT NULL,
+    merged_into   text,
+    merged_at     timestamptz,
+    PRIMARY KEY (org_id, user_id)
+);
@@ -17,7 +17,7 @@
 package dba
 
 import (
-	"errors"
+	"context"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
@@ -42,16 +42,43 @@ var (
 	UpdateProfile         = "AC032259-738B-40A7-AAD7-306B69AAB909"
 )
 
-func (m *MockRepository) ReadProfile(id string) (model.ProfileResponse, error) {
+func (m *MockRepository) ReadProfile(ctx context.Context, id string) (model.ProfileResponse, error) {
 	if id == ReadProfileNotFound {
-		return model.ProfileResponse{}, errors.New("profile not found")
+		return model.ProfileResponse{}, ErrProfileNotFound
 	}
 	return model.ProfileResponse{Name: "Ted Robinson", UserId: id}, nil
 }
 
-func (m *MockRepository) UpdateProfile(id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+func (m *MockRepository) UpdateProfile(ctx context.Context, id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
 	if id == UpdateProfileNotFound {
-		return model.ProfileResponse{}, errors.New("profile not found")
+		return model.ProfileResponse{}, ErrProfileNotFound
 	}
 	return model.ProfileResponse{Name: updateBody.Name, UserId: id}, nil
 }
+
+func (m *MockRepository) CreateProfile(ctx context.Context, id string, createBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	if id == ReadProfileFound {
+		return model.ProfileResponse{}, ErrProfileExists
+	}
+	createBody.UserId = id
+	return model.ProfileResponse(createBody), nil
+}
+
+func (m *MockRepository) ListProfiles(ctx context.Context) ([]model.ProfileResponse, error) {
+	return []model.ProfileResponse{{Name: "Ted Robinson", UserId: ReadProfileFound}}, nil
+}
+
+func (m *MockRepository) DeleteProfile(ctx context.Context, id string) error {
+	if id == ReadProfileNotFound {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+func (m *MockRepository) MergeProfiles(ctx context.Context, survivorId, loserId string, merged model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	if survivorId == ReadProfileNotFound || loserId == ReadProfileNotFound {
+		return model.ProfileResponse{}, ErrMergeConflict
+	}
+	merged.UserId = survivorId
+	return model.ProfileResponse(merged), nil
+}
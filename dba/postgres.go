@@ -0,0 +1,164 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/lib/pq"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrationLock keys the advisory lock that keeps replicas starting together
+// from applying the same migration twice
+const migrationLock = 0x75736572
+
+// retryableCodes are the SQLSTATEs of failures that go away on their own,
+// they reach clients as ErrThrottled like DynamoDB throttling does
+var retryableCodes = map[pq.ErrorCode]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"55P03": true, // lock_not_available
+}
+
+// PostgresRepository keeps profiles in PostgreSQL for deployments that cannot
+// use DynamoDB, it honors the same Repository contract and errors
+type PostgresRepository struct {
+	App *config.AppConfig
+	Db  *sql.DB
+	// OrgId confines every profile call to a single organization, see ForOrg
+	OrgId string
+}
+
+// NewPostgresRepo connects to POSTGRES_DSN and applies any migrations the
+// database has not seen yet
+func NewPostgresRepo(ctx context.Context, a *config.AppConfig) (*PostgresRepository, error) {
+	db, err := sql.Open("postgres", a.PostgresDsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not open postgres: %w", err)
+	}
+	db.SetMaxOpenConns(a.PostgresMaxConns)
+
+	if err = migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &PostgresRepository{
+		App: a,
+		Db:  db,
+	}, nil
+}
+
+// ForOrg returns a copy of the repository whose profile calls can only read
+// and write rows belonging to orgId
+func (m *PostgresRepository) ForOrg(orgId string) Repository {
+	scoped := *m
+	scoped.OrgId = orgId
+	return &scoped
+}
+
+// Close releases the repository's connections
+func (m *PostgresRepository) Close() error {
+	return m.Db.Close()
+}
+
+// withTimeout bounds a call by DB_TIMEOUT, as each DynamoDB operation is
+func (m *PostgresRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.App.DbTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, m.App.DbTimeout)
+}
+
+// migrate applies the embedded migrations in name order, each at most once,
+// recording them in schema_migrations
+func migrate(ctx context.Context, db *sql.DB) error {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("could not list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return postgresError("begin migrations", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return postgresError("lock migrations", err)
+	}
+	if _, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    text        PRIMARY KEY,
+		applied_at timestamptz NOT NULL
+	)`); err != nil {
+		return postgresError("create schema_migrations", err)
+	}
+
+	for _, name := range names {
+		version := path.Base(name)
+
+		var applied bool
+		if err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied); err != nil {
+			return postgresError("read schema_migrations", err)
+		}
+		if applied {
+			continue
+		}
+
+		statements, err := migrations.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("could not read migration %s: %w", version, err)
+		}
+		if _, err = tx.ExecContext(ctx, string(statements)); err != nil {
+			return postgresError("apply migration "+version, err)
+		}
+		if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)", version, time.Now().UTC()); err != nil {
+			return postgresError("record migration "+version, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return postgresError("commit migrations", err)
+	}
+	return nil
+}
+
+// postgresError describes a failed PostgreSQL call, classifying transient
+// failures so they reach clients as retryable instead of as internal errors
+func postgresError(op string, err error) error {
+	wrapped := fmt.Errorf("postgres could not %s: %w", op, err)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && retryableCodes[pqErr.Code] {
+		return ErrThrottled.Wrap(wrapped)
+	}
+	return wrapped
+}
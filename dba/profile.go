@@ -18,27 +18,53 @@ package dba
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
 	"github.com/coinbase-samples/ib-usermgr-go/model"
 )
 
-func (m *DynamoRepository) ReadProfile(id string) (model.ProfileResponse, error) {
+var (
+	ErrOrgRequired     = errs.New(errs.PermissionDenied, "ORG_REQUIRED", "profile access requires an organization")
+	ErrProfileExists   = errs.New(errs.AlreadyExists, "PROFILE_EXISTS", "profile already exists")
+	ErrProfileNotFound = errs.New(errs.NotFound, "PROFILE_NOT_FOUND", "profile not found")
+	ErrMergeConflict   = errs.New(errs.Conflict, "MERGE_CONFLICT", "profiles are missing or were already merged")
+	ErrProfileMerged   = errs.New(errs.FailedPrecondition, "PROFILE_MERGED", "profile was merged into another profile")
+	ErrProfileChanged  = errs.New(errs.Conflict, "PROFILE_CHANGED", "profile was changed by another request")
+)
+
+// profileFields are the attributes an update writes, roles, creation time and
+// merge state are managed by the service
+var profileFields = []string{"Email", "Name", "LegalName", "UserName", "Address", "DateOfBirth", "UpdatedAt"}
+
+func (m *DynamoRepository) ReadProfile(ctx context.Context, id string) (model.ProfileResponse, error) {
 	var profile model.ProfileResponse
 
-	out, err := m.Svc.GetItem(context.TODO(), &dynamodb.GetItemInput{
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String(m.App.ProfileTableName),
 		Key: map[string]types.AttributeValue{
+			"OrgId":  &types.AttributeValueMemberS{Value: m.OrgId},
 			"UserId": &types.AttributeValueMemberS{Value: id},
 		},
 	})
 
 	if err != nil {
-		return profile, fmt.Errorf("dynamodb could not getItem: %w", err)
+		return profile, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return profile, ErrProfileNotFound
 	}
 
 	if err = attributevalue.UnmarshalMap(out.Item, &profile); err != nil {
@@ -48,23 +74,228 @@ func (m *DynamoRepository) ReadProfile(id string) (model.ProfileResponse, error)
 	return profile, nil
 }
 
-func (m *DynamoRepository) UpdateProfile(id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+// UpdateProfile writes the fields a user may change. A non zero UpdatedAt in
+// the body is the version the caller read, and the update fails with
+// ErrProfileChanged when the profile has moved on since. Merged profiles fail
+// with ErrProfileMerged.
+func (m *DynamoRepository) UpdateProfile(ctx context.Context, id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
 	var profile model.ProfileResponse
 
-	updateItem, err := attributevalue.MarshalMap(updateBody)
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	readAt := updateBody.UpdatedAt
+	updateBody.UpdatedAt = time.Now().UTC()
 
+	item, err := attributevalue.MarshalMap(updateBody)
 	if err != nil {
 		return profile, fmt.Errorf("could not marshal update request body: %w", err)
 	}
 
-	if _, err = m.Svc.PutItem(context.TODO(), &dynamodb.PutItemInput{
+	// Name is a reserved word, so every attribute goes through a placeholder
+	names := make(map[string]string, len(profileFields))
+	values := make(map[string]types.AttributeValue, len(profileFields)+1)
+	assignments := make([]string, 0, len(profileFields))
+	for _, field := range profileFields {
+		names["#"+field] = field
+		values[":"+field] = item[field]
+		assignments = append(assignments, "#"+field+" = :"+field)
+	}
+	condition := "attribute_exists(UserId) AND attribute_not_exists(MergedInto)"
+	if !readAt.IsZero() {
+		if values[":readAt"], err = attributevalue.Marshal(readAt); err != nil {
+			return profile, fmt.Errorf("could not marshal read time: %w", err)
+		}
+		condition += " AND #UpdatedAt = :readAt"
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(m.App.ProfileTableName),
-		Item:      updateItem,
-	}); err != nil {
-		return profile, fmt.Errorf("dynamodb could not putItem: %w", err)
+		Key: map[string]types.AttributeValue{
+			"OrgId":  &types.AttributeValueMemberS{Value: m.OrgId},
+			"UserId": &types.AttributeValueMemberS{Value: id},
+		},
+		UpdateExpression:          aws.String("SET " + strings.Join(assignments, ", ")),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return profile, m.updateConflict(ctx, id)
+		}
+		return profile, dynamoError("updateItem", err)
 	}
 
-	profile = model.ProfileResponse(updateBody)
+	if err = attributevalue.UnmarshalMap(out.Attributes, &profile); err != nil {
+		return profile, fmt.Errorf("could not unmarshal item: %w", err)
+	}
 
 	return profile, nil
 }
+
+// updateConflict tells which condition of an update failed
+func (m *DynamoRepository) updateConflict(ctx context.Context, id string) error {
+	current, err := m.ReadProfile(ctx, id)
+	switch {
+	case err != nil:
+		return err
+	case current.IsMerged():
+		return ErrProfileMerged
+	default:
+		return ErrProfileChanged
+	}
+}
+
+func (m *DynamoRepository) CreateProfile(ctx context.Context, id string, createBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	var profile model.ProfileResponse
+
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	createBody.OrgId = m.OrgId
+	createBody.UserId = id
+	createBody.CreatedAt = time.Now().UTC()
+	createBody.UpdatedAt = createBody.CreatedAt
+
+	item, err := attributevalue.MarshalMap(createBody)
+	if err != nil {
+		return profile, fmt.Errorf("could not marshal create request body: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(m.App.ProfileTableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(UserId)"),
+	}); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return profile, ErrProfileExists
+		}
+		return profile, dynamoError("putItem", err)
+	}
+
+	return model.ProfileResponse(createBody), nil
+}
+
+// ListProfiles returns every profile in the repository's organization
+func (m *DynamoRepository) ListProfiles(ctx context.Context) ([]model.ProfileResponse, error) {
+	if m.OrgId == "" {
+		return nil, ErrOrgRequired
+	}
+
+	profiles := []model.ProfileResponse{}
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.ProfileTableName),
+		KeyConditionExpression: aws.String("OrgId = :orgId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":orgId": &types.AttributeValueMemberS{Value: m.OrgId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query profiles", err)
+		}
+
+		var page []model.ProfileResponse
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal profiles: %w", err)
+		}
+		profiles = append(profiles, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return profiles, nil
+}
+
+func (m *DynamoRepository) DeleteProfile(ctx context.Context, id string) error {
+	if m.OrgId == "" {
+		return ErrOrgRequired
+	}
+
+	if _, err := m.Svc.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(m.App.ProfileTableName),
+		Key: map[string]types.AttributeValue{
+			"OrgId":  &types.AttributeValueMemberS{Value: m.OrgId},
+			"UserId": &types.AttributeValueMemberS{Value: id},
+		},
+		ConditionExpression: aws.String("attribute_exists(UserId)"),
+	}); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrProfileNotFound
+		}
+		return dynamoError("deleteItem", err)
+	}
+
+	return nil
+}
+
+// MergeProfiles writes the merged survivor and tombstones the loser with a
+// pointer to it in a single transaction. It fails with ErrMergeConflict when
+// either profile is missing or has already been merged.
+func (m *DynamoRepository) MergeProfiles(ctx context.Context, survivorId, loserId string, merged model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	var profile model.ProfileResponse
+
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	now := time.Now().UTC()
+	merged.OrgId = m.OrgId
+	merged.UserId = survivorId
+	merged.MergedInto = ""
+	merged.MergedAt = nil
+	merged.UpdatedAt = now
+
+	item, err := attributevalue.MarshalMap(merged)
+	if err != nil {
+		return profile, fmt.Errorf("could not marshal merged profile: %w", err)
+	}
+	mergedAt, err := attributevalue.Marshal(now)
+	if err != nil {
+		return profile, fmt.Errorf("could not marshal merge time: %w", err)
+	}
+
+	if _, err = m.Svc.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:           aws.String(m.App.ProfileTableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_exists(UserId) AND attribute_not_exists(MergedInto)"),
+				},
+			},
+			{
+				Update: &types.Update{
+					TableName: aws.String(m.App.ProfileTableName),
+					Key: map[string]types.AttributeValue{
+						"OrgId":  &types.AttributeValueMemberS{Value: m.OrgId},
+						"UserId": &types.AttributeValueMemberS{Value: loserId},
+					},
+					UpdateExpression:    aws.String("SET MergedInto = :survivorId, MergedAt = :mergedAt, UpdatedAt = :mergedAt"),
+					ConditionExpression: aws.String("attribute_exists(UserId) AND attribute_not_exists(MergedInto)"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":survivorId": &types.AttributeValueMemberS{Value: survivorId},
+						":mergedAt":   mergedAt,
+					},
+				},
+			},
+		},
+	}); err != nil {
+		var cancelled *types.TransactionCanceledException
+		if errors.As(err, &cancelled) {
+			return profile, ErrMergeConflict
+		}
+		return profile, dynamoError("transactWriteItems", err)
+	}
+
+	return model.ProfileResponse(merged), nil
+}
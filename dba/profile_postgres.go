@@ -0,0 +1,355 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+const profileColumns = `org_id, user_id, email, name, legal_name, user_name, roles, address,
+	date_of_birth, created_at, updated_at, merged_into, merged_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProfile(row rowScanner) (model.ProfileResponse, error) {
+	var (
+		profile    model.ProfileResponse
+		roles      []byte
+		mergedInto sql.NullString
+		mergedAt   sql.NullTime
+	)
+	if err := row.Scan(&profile.OrgId, &profile.UserId, &profile.Email, &profile.Name, &profile.LegalName,
+		&profile.UserName, &roles, &profile.Address, &profile.DateOfBirth, &profile.CreatedAt,
+		&profile.UpdatedAt, &mergedInto, &mergedAt); err != nil {
+		return profile, err
+	}
+
+	if err := decodeRoles(roles, &profile.Roles); err != nil {
+		return profile, err
+	}
+	profile.CreatedAt = profile.CreatedAt.UTC()
+	profile.UpdatedAt = profile.UpdatedAt.UTC()
+	profile.MergedInto = mergedInto.String
+	if mergedAt.Valid {
+		at := mergedAt.Time.UTC()
+		profile.MergedAt = &at
+	}
+	return profile, nil
+}
+
+// encodeRoles stores roles as a JSONB array, never as null. The array is
+// passed as text, lib/pq would send a []byte as bytea.
+func encodeRoles(roles []string) (string, error) {
+	if roles == nil {
+		roles = []string{}
+	}
+	encoded, err := json.Marshal(roles)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal roles: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// decodeRoles reads a JSONB array of roles, leaving an empty one nil as
+// DynamoDB does
+func decodeRoles(encoded []byte, roles *[]string) error {
+	var decoded []string
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return fmt.Errorf("could not unmarshal roles: %w", err)
+	}
+	if len(decoded) > 0 {
+		*roles = decoded
+	}
+	return nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullTime stores a missing time as NULL
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// postgresNow is truncated to the microsecond PostgreSQL keeps, so what a call
+// returns matches what a later read returns
+func postgresNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func (m *PostgresRepository) ReadProfile(ctx context.Context, id string) (model.ProfileResponse, error) {
+	if m.OrgId == "" {
+		return model.ProfileResponse{}, ErrOrgRequired
+	}
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	profile, err := scanProfile(m.Db.QueryRowContext(ctx,
+		"SELECT "+profileColumns+" FROM profiles WHERE org_id = $1 AND user_id = $2", m.OrgId, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ProfileResponse{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return model.ProfileResponse{}, postgresError("select profile", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile writes the fields a user may change, with the same version
+// and merge checks as the DynamoDB store
+func (m *PostgresRepository) UpdateProfile(ctx context.Context, id string, updateBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	var profile model.ProfileResponse
+
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	// the scope always wins over whatever the caller put in the body
+	updateBody.OrgId = m.OrgId
+	updateBody.UserId = id
+	readAt := updateBody.UpdatedAt
+	updateBody.UpdatedAt = postgresNow()
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	// roles, creation time and merge state are managed by the service, the
+	// update leaves them as they are and hands them back
+	var (
+		roles      []byte
+		mergedInto sql.NullString
+		mergedAt   sql.NullTime
+	)
+	err := m.Db.QueryRowContext(ctx, `UPDATE profiles
+		SET email = $3, name = $4, legal_name = $5, user_name = $6, address = $7, date_of_birth = $8, updated_at = $9
+		WHERE org_id = $1 AND user_id = $2 AND merged_into IS NULL
+			AND ($10::timestamptz IS NULL OR updated_at = $10)
+		RETURNING roles, created_at, merged_into, merged_at`,
+		m.OrgId, id, updateBody.Email, updateBody.Name, updateBody.LegalName, updateBody.UserName,
+		updateBody.Address, updateBody.DateOfBirth, updateBody.UpdatedAt, nullTime(readVersion(readAt)),
+	).Scan(&roles, &updateBody.CreatedAt, &mergedInto, &mergedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return profile, m.updateConflict(ctx, id)
+	}
+	if err != nil {
+		return profile, postgresError("update profile", err)
+	}
+
+	updateBody.Roles = nil
+	if err = decodeRoles(roles, &updateBody.Roles); err != nil {
+		return profile, err
+	}
+	updateBody.CreatedAt = updateBody.CreatedAt.UTC()
+	updateBody.MergedInto = mergedInto.String
+	updateBody.MergedAt = nil
+	if mergedAt.Valid {
+		at := mergedAt.Time.UTC()
+		updateBody.MergedAt = &at
+	}
+
+	return model.ProfileResponse(updateBody), nil
+}
+
+// readVersion is nil for a caller that did not read the profile first
+func readVersion(readAt time.Time) *time.Time {
+	if readAt.IsZero() {
+		return nil
+	}
+	return &readAt
+}
+
+// updateConflict tells which condition of an update failed
+func (m *PostgresRepository) updateConflict(ctx context.Context, id string) error {
+	current, err := m.ReadProfile(ctx, id)
+	switch {
+	case err != nil:
+		return err
+	case current.IsMerged():
+		return ErrProfileMerged
+	default:
+		return ErrProfileChanged
+	}
+}
+
+func (m *PostgresRepository) CreateProfile(ctx context.Context, id string, createBody model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	var profile model.ProfileResponse
+
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	createBody.OrgId = m.OrgId
+	createBody.UserId = id
+	createBody.CreatedAt = postgresNow()
+	createBody.UpdatedAt = createBody.CreatedAt
+
+	roles, err := encodeRoles(createBody.Roles)
+	if err != nil {
+		return profile, err
+	}
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, err := m.Db.ExecContext(ctx, "INSERT INTO profiles ("+profileColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (org_id, user_id) DO NOTHING`,
+		createBody.OrgId, createBody.UserId, createBody.Email, createBody.Name, createBody.LegalName,
+		createBody.UserName, roles, createBody.Address, createBody.DateOfBirth, createBody.CreatedAt,
+		createBody.UpdatedAt, nullString(createBody.MergedInto), nullTime(createBody.MergedAt))
+	if err != nil {
+		return profile, postgresError("insert profile", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return profile, postgresError("insert profile", err)
+	} else if inserted == 0 {
+		return profile, ErrProfileExists
+	}
+
+	return model.ProfileResponse(createBody), nil
+}
+
+// ListProfiles returns every profile in the repository's organization
+func (m *PostgresRepository) ListProfiles(ctx context.Context) ([]model.ProfileResponse, error) {
+	if m.OrgId == "" {
+		return nil, ErrOrgRequired
+	}
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := m.Db.QueryContext(ctx,
+		"SELECT "+profileColumns+" FROM profiles WHERE org_id = $1 ORDER BY user_id", m.OrgId)
+	if err != nil {
+		return nil, postgresError("select profiles", err)
+	}
+	defer rows.Close()
+
+	profiles := []model.ProfileResponse{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, postgresError("select profiles", err)
+		}
+		profiles = append(profiles, profile)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, postgresError("select profiles", err)
+	}
+
+	return profiles, nil
+}
+
+func (m *PostgresRepository) DeleteProfile(ctx context.Context, id string) error {
+	if m.OrgId == "" {
+		return ErrOrgRequired
+	}
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	result, err := m.Db.ExecContext(ctx, "DELETE FROM profiles WHERE org_id = $1 AND user_id = $2", m.OrgId, id)
+	if err != nil {
+		return postgresError("delete profile", err)
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return postgresError("delete profile", err)
+	} else if deleted == 0 {
+		return ErrProfileNotFound
+	}
+
+	return nil
+}
+
+// MergeProfiles writes the merged survivor and tombstones the loser with a
+// pointer to it in a single transaction. It fails with ErrMergeConflict when
+// either profile is missing or has already been merged.
+func (m *PostgresRepository) MergeProfiles(ctx context.Context, survivorId, loserId string, merged model.UpdateProfileRequest) (model.ProfileResponse, error) {
+	var profile model.ProfileResponse
+
+	if m.OrgId == "" {
+		return profile, ErrOrgRequired
+	}
+	if survivorId == loserId {
+		return profile, ErrMergeConflict
+	}
+	mergedAt := postgresNow()
+	merged.OrgId = m.OrgId
+	merged.UserId = survivorId
+	merged.MergedInto = ""
+	merged.MergedAt = nil
+	merged.UpdatedAt = mergedAt
+
+	roles, err := encodeRoles(merged.Roles)
+	if err != nil {
+		return profile, err
+	}
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := m.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return profile, postgresError("begin merge", err)
+	}
+	defer tx.Rollback()
+
+	// the row locks taken by each update make a concurrent merge of either
+	// profile wait, then find it merged and match nothing
+	result, err := tx.ExecContext(ctx, `UPDATE profiles
+		SET email = $3, name = $4, legal_name = $5, user_name = $6, roles = $7, address = $8,
+			date_of_birth = $9, created_at = $10, updated_at = $11
+		WHERE org_id = $1 AND user_id = $2 AND merged_into IS NULL`,
+		m.OrgId, survivorId, merged.Email, merged.Name, merged.LegalName, merged.UserName, roles,
+		merged.Address, merged.DateOfBirth, merged.CreatedAt, merged.UpdatedAt)
+	if err != nil {
+		return profile, postgresError("update survivor", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return profile, postgresError("update survivor", err)
+	} else if updated == 0 {
+		return profile, ErrMergeConflict
+	}
+
+	result, err = tx.ExecContext(ctx, `UPDATE profiles
+		SET merged_into = $3, merged_at = $4, updated_at = $4
+		WHERE org_id = $1 AND user_id = $2 AND merged_into IS NULL`,
+		m.OrgId, loserId, survivorId, mergedAt)
+	if err != nil {
+		return profile, postgresError("tombstone loser", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return profile, postgresError("tombstone loser", err)
+	} else if updated == 0 {
+		return profile, ErrMergeConflict
+	}
+
+	if err = tx.Commit(); err != nil {
+		return profile, postgresError("commit merge", err)
+	}
+
+	return model.ProfileResponse(merged), nil
+}
@@ -32,7 +32,7 @@ func TestReadProfile(t *testing.T) {
 	repo := new(MockRepository)
 	NewTestDBA(repo)
 
-	resp, err := repo.ReadProfile(ReadProfileFound)
+	resp, err := repo.ReadProfile(context.Background(), ReadProfileFound)
 
 	if err != nil {
 		t.Fatal("unexpected error returned from function invocation")
@@ -51,7 +51,7 @@ func TestReadProfileNotFound(t *testing.T) {
 	repo := new(MockRepository)
 	NewTestDBA(repo)
 
-	resp, err := repo.ReadProfile(ReadProfileNotFound)
+	resp, err := repo.ReadProfile(context.Background(), ReadProfileNotFound)
 
 	if len(resp.Name) > 0 {
 		t.Fatal("expected empty name")
@@ -61,8 +61,8 @@ func TestReadProfileNotFound(t *testing.T) {
 		t.Fatal("expected userId to be empty")
 	}
 
-	if err == errors.New("profile not found") {
-		t.Fatal("expected error")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected profile not found, got %v", err)
 	}
 }
 
@@ -70,7 +70,7 @@ func TestUpdateProfile(t *testing.T) {
 	repo := new(MockRepository)
 	NewTestDBA(repo)
 
-	resp, err := repo.UpdateProfile(UpdateProfile, model.UpdateProfileRequest{
+	resp, err := repo.UpdateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{
 		UserId: UpdateProfile,
 		Name:   "Bob Ross",
 	})
@@ -92,7 +92,7 @@ func TestUpdateProfileNotFound(t *testing.T) {
 	repo := new(MockRepository)
 	NewTestDBA(repo)
 
-	resp, err := repo.UpdateProfile(UpdateProfileNotFound, model.UpdateProfileRequest{
+	resp, err := repo.UpdateProfile(context.Background(), UpdateProfileNotFound, model.UpdateProfileRequest{
 		UserId: UpdateProfile,
 		Name:   "Bob Ross",
 	})
@@ -115,6 +115,9 @@ type DynamoMock struct {
 }
 
 func (m *DynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if id, ok := params.Key["UserId"].(*types.AttributeValueMemberS); ok && id.Value == ReadProfileNotFound {
+		return &dynamodb.GetItemOutput{}, nil
+	}
 	return &dynamodb.GetItemOutput{
 		Item: map[string]types.AttributeValue{
 			"UserId": &types.AttributeValueMemberS{Value: ReadProfileFound},
@@ -132,6 +135,27 @@ func (m *DynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput,
 	}, nil
 }
 
+func (m *DynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return &dynamodb.UpdateItemOutput{
+		Attributes: map[string]types.AttributeValue{
+			"UserId": params.Key["UserId"],
+			"Name":   params.ExpressionAttributeValues[":Name"],
+		},
+	}, nil
+}
+
+func (m *DynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *DynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *DynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
 func TestReadDynamo(t *testing.T) {
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -143,7 +167,7 @@ func TestReadDynamo(t *testing.T) {
 	}
 	NewDBA(repo)
 
-	resp, err := Repo.ReadProfile(ReadProfileFound)
+	resp, err := Repo.ForOrg("org-1").ReadProfile(context.Background(), ReadProfileFound)
 
 	if err != nil {
 		t.Fatal("unexpected error")
@@ -154,6 +178,22 @@ func TestReadDynamo(t *testing.T) {
 	}
 }
 
+func TestReadDynamoNotFound(t *testing.T) {
+	NewDBA(&DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(DynamoMock),
+	})
+
+	resp, err := Repo.ForOrg("org-1").ReadProfile(context.Background(), ReadProfileNotFound)
+
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected profile not found, got %v", err)
+	}
+	if resp.UserId != "" {
+		t.Fatal("expected empty profile")
+	}
+}
+
 func TestUpdateDynamo(t *testing.T) {
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -165,7 +205,7 @@ func TestUpdateDynamo(t *testing.T) {
 	}
 	NewDBA(repo)
 
-	resp, err := Repo.UpdateProfile(UpdateProfile, model.UpdateProfileRequest{
+	resp, err := Repo.ForOrg("org-1").UpdateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{
 		UserId: UpdateProfile,
 		Name:   "Bob Ross",
 	})
@@ -191,6 +231,22 @@ func (m *DynamoErrorMock) PutItem(ctx context.Context, params *dynamodb.PutItemI
 	return nil, errors.New("some error")
 }
 
+func (m *DynamoErrorMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return nil, errors.New("some error")
+}
+
+func (m *DynamoErrorMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return nil, errors.New("some error")
+}
+
+func (m *DynamoErrorMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return nil, errors.New("some error")
+}
+
+func (m *DynamoErrorMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return nil, errors.New("some error")
+}
+
 func TestReadErrorDynamo(t *testing.T) {
 	dynMock := new(DynamoErrorMock)
 	app := config.AppConfig{
@@ -202,7 +258,7 @@ func TestReadErrorDynamo(t *testing.T) {
 	}
 	NewDBA(repo)
 
-	_, err := Repo.ReadProfile(ReadProfileFound)
+	_, err := Repo.ForOrg("org-1").ReadProfile(context.Background(), ReadProfileFound)
 
 	if err == nil {
 		t.Fatal("expected error")
@@ -221,7 +277,7 @@ func TestUpdateErrorDynamo(t *testing.T) {
 	}
 	NewDBA(repo)
 
-	_, err := Repo.UpdateProfile(UpdateProfile, model.UpdateProfileRequest{
+	_, err := Repo.ForOrg("org-1").UpdateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{
 		UserId: UpdateProfile,
 		Name:   "Bob Ross",
 	})
@@ -230,3 +286,166 @@ func TestUpdateErrorDynamo(t *testing.T) {
 	}
 
 }
+
+type OrgKeyDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	get    *dynamodb.GetItemInput
+	put    *dynamodb.PutItemInput
+	update *dynamodb.UpdateItemInput
+	del    *dynamodb.DeleteItemInput
+	tx     *dynamodb.TransactWriteItemsInput
+}
+
+func (m *OrgKeyDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	m.get = params
+	// echo the key back so reads find a profile
+	return &dynamodb.GetItemOutput{Item: params.Key}, nil
+}
+
+func (m *OrgKeyDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	m.put = params
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *OrgKeyDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	m.update = params
+	return &dynamodb.UpdateItemOutput{Attributes: params.Key}, nil
+}
+
+func (m *OrgKeyDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *OrgKeyDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	m.del = params
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *OrgKeyDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	m.tx = params
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func TestProfileRequiresOrg(t *testing.T) {
+	repo := &DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(OrgKeyDynamoMock),
+	}
+
+	if _, err := repo.ReadProfile(context.Background(), ReadProfileFound); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+
+	if _, err := repo.UpdateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{}); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+}
+
+func TestProfileKeysAreOrgScoped(t *testing.T) {
+	dynMock := new(OrgKeyDynamoMock)
+	repo := &DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: dynMock,
+	}
+	scoped := repo.ForOrg("org-1")
+
+	if repo.OrgId != "" {
+		t.Fatal("expected ForOrg to leave the shared repository unscoped")
+	}
+
+	if _, err := scoped.ReadProfile(context.Background(), ReadProfileFound); err != nil {
+		t.Fatal(err)
+	}
+	if dynMock.get.Key["OrgId"].(*types.AttributeValueMemberS).Value != "org-1" {
+		t.Fatal("expected read to be keyed by org")
+	}
+
+	resp, err := scoped.UpdateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{
+		OrgId:  "org-2",
+		UserId: UpdateProfile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dynMock.update.Key["OrgId"].(*types.AttributeValueMemberS).Value != "org-1" || resp.OrgId != "org-1" {
+		t.Fatal("expected update to be confined to the scoped org")
+	}
+}
+
+func TestCreateListDeleteProfileAreOrgScoped(t *testing.T) {
+	dynMock := new(OrgKeyDynamoMock)
+	repo := &DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: dynMock,
+	}
+
+	if _, err := repo.CreateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{}); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+	if _, err := repo.ListProfiles(context.Background()); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+	if err := repo.DeleteProfile(context.Background(), UpdateProfile); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+
+	scoped := repo.ForOrg("org-1")
+
+	resp, err := scoped.CreateProfile(context.Background(), UpdateProfile, model.UpdateProfileRequest{
+		OrgId: "org-2",
+		Name:  "Bob Ross",
+		Roles: []string{model.RoleMember},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OrgId != "org-1" || resp.UserId != UpdateProfile || resp.CreatedAt.IsZero() {
+		t.Fatalf("unexpected profile: %+v", resp)
+	}
+	if dynMock.put.ConditionExpression == nil || dynMock.put.Item["OrgId"].(*types.AttributeValueMemberS).Value != "org-1" {
+		t.Fatal("expected a conditional create within the scoped org")
+	}
+
+	if err = scoped.DeleteProfile(context.Background(), UpdateProfile); err != nil {
+		t.Fatal(err)
+	}
+	if dynMock.del.Key["OrgId"].(*types.AttributeValueMemberS).Value != "org-1" {
+		t.Fatal("expected delete to be keyed by org")
+	}
+}
+
+func TestMergeProfilesTombstonesLoser(t *testing.T) {
+	dynMock := new(OrgKeyDynamoMock)
+	repo := &DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: dynMock,
+	}
+
+	if _, err := repo.MergeProfiles(context.Background(), UpdateProfile, ReadProfileFound, model.UpdateProfileRequest{}); !errors.Is(err, ErrOrgRequired) {
+		t.Fatalf("expected ErrOrgRequired, got %v", err)
+	}
+
+	resp, err := repo.ForOrg("org-1").MergeProfiles(context.Background(), UpdateProfile, ReadProfileFound, model.UpdateProfileRequest{
+		Name:       "Bob Ross",
+		MergedInto: "someone-else",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.UserId != UpdateProfile || resp.OrgId != "org-1" || resp.IsMerged() {
+		t.Fatalf("unexpected survivor: %+v", resp)
+	}
+
+	items := dynMock.tx.TransactItems
+	if len(items) != 2 || items[0].Put == nil || items[1].Update == nil {
+		t.Fatal("expected the survivor put and loser update in one transaction")
+	}
+	if _, ok := items[0].Put.Item["MergedInto"]; ok {
+		t.Fatal("expected the survivor to never be tombstoned")
+	}
+	loser := items[1].Update
+	if loser.Key["UserId"].(*types.AttributeValueMemberS).Value != ReadProfileFound ||
+		loser.ExpressionAttributeValues[":survivorId"].(*types.AttributeValueMemberS).Value != UpdateProfile {
+		t.Fatal("expected the loser to point at the survivor")
+	}
+}
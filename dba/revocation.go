@@ -0,0 +1,79 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// RecordRevocation stores a revocation, replacing any earlier one with the
+// same id so a new watermark supersedes the old
+func (m *DynamoRepository) RecordRevocation(ctx context.Context, revocation model.Revocation) (model.Revocation, error) {
+	item, err := attributevalue.MarshalMap(revocation)
+	if err != nil {
+		return model.Revocation{}, fmt.Errorf("could not marshal revocation: %w", err)
+	}
+
+	if _, err = m.Svc.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(m.App.RevocationTableName),
+		Item:      item,
+	}); err != nil {
+		return model.Revocation{}, dynamoError("putItem", err)
+	}
+
+	return revocation, nil
+}
+
+// ListRevocations returns the watermark and token revocations of a user
+func (m *DynamoRepository) ListRevocations(ctx context.Context, userId string) ([]model.Revocation, error) {
+	revocations := []model.Revocation{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.RevocationTableName),
+		KeyConditionExpression: aws.String("UserId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query revocations", err)
+		}
+
+		var page []model.Revocation
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal revocations: %w", err)
+		}
+		revocations = append(revocations, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return revocations, nil
+}
@@ -0,0 +1,57 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+func TestRecordRevocationOmitsUnsetFields(t *testing.T) {
+	dynMock := new(ImpersonationDynamoMock)
+	repo := &DynamoRepository{
+		App: &config.AppConfig{RevocationTableName: "Revocation"},
+		Svc: dynMock,
+	}
+
+	notBefore := time.Unix(1700000000, 0).UTC()
+	if _, err := repo.RecordRevocation(context.Background(), model.Revocation{
+		UserId:       "123",
+		RevocationId: model.RevocationWatermark,
+		NotBefore:    &notBefore,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *dynMock.put.TableName != "Revocation" || dynMock.put.ConditionExpression != nil {
+		t.Fatal("expected an unconditional put so a new watermark replaces the old")
+	}
+	if _, ok := dynMock.put.Item["NotBefore"].(*types.AttributeValueMemberS); !ok {
+		t.Fatalf("expected the watermark to be stored, got %v", dynMock.put.Item["NotBefore"])
+	}
+	// a watermark without a ttl is never dropped by DynamoDB
+	for _, name := range []string{"TokenId", "Ttl"} {
+		if _, ok := dynMock.put.Item[name]; ok {
+			t.Fatalf("expected %s to be omitted", name)
+		}
+	}
+}
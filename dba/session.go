@@ -0,0 +1,191 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+var ErrSessionNotFound = errs.New(errs.NotFound, "SESSION_NOT_FOUND", "session not found or already revoked")
+
+// TouchSession records a request against a session, creating it on first use,
+// and returns the stored session so callers can check for revocation
+func (m *DynamoRepository) TouchSession(ctx context.Context, session model.Session) (model.Session, error) {
+	var stored model.Session
+
+	now, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return stored, fmt.Errorf("could not marshal session time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.SessionTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId":    &types.AttributeValueMemberS{Value: session.UserId},
+			"SessionId": &types.AttributeValueMemberS{Value: session.SessionId},
+		},
+		UpdateExpression: aws.String("SET FirstSeenAt = if_not_exists(FirstSeenAt, :now), LastSeenAt = :now, " +
+			"TokenId = :tokenId, IpAddress = :ipAddress, UserAgent = :userAgent"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now":       now,
+			":tokenId":   &types.AttributeValueMemberS{Value: session.TokenId},
+			":ipAddress": &types.AttributeValueMemberS{Value: session.IpAddress},
+			":userAgent": &types.AttributeValueMemberS{Value: session.UserAgent},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return stored, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &stored); err != nil {
+		return stored, fmt.Errorf("could not unmarshal session: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetSession reads a session without recording a request against it
+func (m *DynamoRepository) GetSession(ctx context.Context, userId, sessionId string) (model.Session, error) {
+	var session model.Session
+
+	out, err := m.Svc.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(m.App.SessionTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId":    &types.AttributeValueMemberS{Value: userId},
+			"SessionId": &types.AttributeValueMemberS{Value: sessionId},
+		},
+	})
+	if err != nil {
+		return session, dynamoError("getItem", err)
+	}
+
+	if len(out.Item) == 0 {
+		return session, ErrSessionNotFound
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Item, &session); err != nil {
+		return session, fmt.Errorf("could not unmarshal session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (m *DynamoRepository) ListSessions(ctx context.Context, userId string) ([]model.Session, error) {
+	sessions := []model.Session{}
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(m.App.SessionTableName),
+		KeyConditionExpression: aws.String("UserId = :userId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userId": &types.AttributeValueMemberS{Value: userId},
+		},
+	}
+
+	for {
+		out, err := m.Svc.Query(ctx, input)
+		if err != nil {
+			return nil, dynamoError("query sessions", err)
+		}
+
+		var page []model.Session
+		if err = attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal sessions: %w", err)
+		}
+		sessions = append(sessions, page...)
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	return sessions, nil
+}
+
+func (m *DynamoRepository) RevokeSession(ctx context.Context, userId, sessionId string) (model.Session, error) {
+	var session model.Session
+
+	revokedAt, err := attributevalue.Marshal(time.Now().UTC())
+	if err != nil {
+		return session, fmt.Errorf("could not marshal revocation time: %w", err)
+	}
+
+	out, err := m.Svc.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(m.App.SessionTableName),
+		Key: map[string]types.AttributeValue{
+			"UserId":    &types.AttributeValueMemberS{Value: userId},
+			"SessionId": &types.AttributeValueMemberS{Value: sessionId},
+		},
+		UpdateExpression:    aws.String("SET RevokedAt = :revokedAt"),
+		ConditionExpression: aws.String("attribute_exists(SessionId) AND attribute_not_exists(RevokedAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":revokedAt": revokedAt,
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return session, ErrSessionNotFound
+		}
+		return session, dynamoError("updateItem", err)
+	}
+
+	if err = attributevalue.UnmarshalMap(out.Attributes, &session); err != nil {
+		return session, fmt.Errorf("could not unmarshal session: %w", err)
+	}
+
+	return session, nil
+}
+
+// RevokeOtherSessions revokes every active session of the user except the
+// one the request was made from
+func (m *DynamoRepository) RevokeOtherSessions(ctx context.Context, userId, currentSessionId string) ([]model.Session, error) {
+	sessions, err := m.ListSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := []model.Session{}
+	for _, s := range sessions {
+		if s.SessionId == currentSessionId || s.IsRevoked() {
+			continue
+		}
+		session, err := m.RevokeSession(ctx, userId, s.SessionId)
+		if errors.Is(err, ErrSessionNotFound) {
+			// revoked concurrently
+			continue
+		}
+		if err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, session)
+	}
+
+	return revoked, nil
+}
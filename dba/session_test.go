@@ -0,0 +1,203 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// SessionDynamoMock keeps sessions in memory keyed by session id
+type SessionDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	items map[string]map[string]types.AttributeValue
+}
+
+func newSessionDynamoMock() *SessionDynamoMock {
+	return &SessionDynamoMock{items: make(map[string]map[string]types.AttributeValue)}
+}
+
+func (m *SessionDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: m.items[params.Key["SessionId"].(*types.AttributeValueMemberS).Value]}, nil
+}
+
+func (m *SessionDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *SessionDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	id := params.Key["SessionId"].(*types.AttributeValueMemberS).Value
+	item, exists := m.items[id]
+
+	if strings.Contains(*params.UpdateExpression, "RevokedAt") {
+		if _, revoked := item["RevokedAt"]; !exists || revoked {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+		item["RevokedAt"] = params.ExpressionAttributeValues[":revokedAt"]
+		return &dynamodb.UpdateItemOutput{Attributes: item}, nil
+	}
+
+	if !exists {
+		item = map[string]types.AttributeValue{
+			"UserId":      params.Key["UserId"],
+			"SessionId":   params.Key["SessionId"],
+			"FirstSeenAt": params.ExpressionAttributeValues[":now"],
+		}
+		m.items[id] = item
+	}
+	item["LastSeenAt"] = params.ExpressionAttributeValues[":now"]
+	item["TokenId"] = params.ExpressionAttributeValues[":tokenId"]
+	return &dynamodb.UpdateItemOutput{Attributes: item}, nil
+}
+
+func (m *SessionDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	out := &dynamodb.QueryOutput{}
+	for _, item := range m.items {
+		out.Items = append(out.Items, item)
+	}
+	return out, nil
+}
+
+func (m *SessionDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *SessionDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newSessionRepo(svc Database) *DynamoRepository {
+	return &DynamoRepository{
+		App: &config.AppConfig{SessionTableName: "Session"},
+		Svc: svc,
+	}
+}
+
+func TestTouchSession(t *testing.T) {
+	repo := newSessionRepo(newSessionDynamoMock())
+	ctx := context.Background()
+
+	first, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: "a", TokenId: "t1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: "a", TokenId: "t2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !second.FirstSeenAt.Equal(first.FirstSeenAt) {
+		t.Fatal("expected first seen to be kept across touches")
+	}
+	if second.TokenId != "t2" {
+		t.Fatal("expected latest token id to be recorded")
+	}
+	if second.IsRevoked() {
+		t.Fatal("expected session to be active")
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	repo := newSessionRepo(newSessionDynamoMock())
+	ctx := context.Background()
+
+	if _, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := repo.RevokeSession(ctx, "123", "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.IsRevoked() {
+		t.Fatal("expected session to be revoked")
+	}
+
+	touched, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !touched.IsRevoked() {
+		t.Fatal("expected touching a revoked session to report revocation")
+	}
+
+	if _, err = repo.RevokeSession(ctx, "123", "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	repo := newSessionRepo(newSessionDynamoMock())
+	ctx := context.Background()
+
+	if _, err := repo.GetSession(ctx, "123", "a"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if _, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: "a", TokenId: "t1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.RevokeSession(ctx, "123", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := repo.GetSession(ctx, "123", "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.TokenId != "t1" || !session.IsRevoked() {
+		t.Fatalf("expected the revoked session, got %+v", session)
+	}
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	dynMock := newSessionDynamoMock()
+	repo := newSessionRepo(dynMock)
+	ctx := context.Background()
+
+	for _, id := range []string{"current", "laptop", "phone"} {
+		if _, err := repo.TouchSession(ctx, model.Session{UserId: "123", SessionId: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revoked, err := repo.RevokeOtherSessions(ctx, "123", "current")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revoked) != 2 {
+		t.Fatalf("expected two sessions revoked, got %d", len(revoked))
+	}
+
+	var current model.Session
+	if err := attributevalue.UnmarshalMap(dynMock.items["current"], &current); err != nil {
+		t.Fatal(err)
+	}
+	if current.IsRevoked() {
+		t.Fatal("expected current session to stay signed in")
+	}
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// timeoutDatabase bounds every DynamoDB call, so a slow table cannot hold a
+// request past its budget even when the caller's context has no deadline
+type timeoutDatabase struct {
+	next     Database
+	fallback time.Duration
+	// timeouts overrides fallback per operation, keyed by method name such
+	// as Query or TransactWriteItems
+	timeouts map[string]time.Duration
+}
+
+// withTimeouts wraps db so each operation runs under its configured timeout,
+// a timeout of 0 leaves the caller's context as it is
+func withTimeouts(db Database, fallback time.Duration, timeouts map[string]time.Duration) Database {
+	return &timeoutDatabase{next: db, fallback: fallback, timeouts: timeouts}
+}
+
+func (t *timeoutDatabase) withTimeout(ctx context.Context, op string) (context.Context, context.CancelFunc) {
+	timeout, ok := t.timeouts[op]
+	if !ok {
+		timeout = t.fallback
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (t *timeoutDatabase) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "GetItem")
+	defer cancel()
+	return t.next.GetItem(ctx, params, optFns...)
+}
+
+func (t *timeoutDatabase) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "PutItem")
+	defer cancel()
+	return t.next.PutItem(ctx, params, optFns...)
+}
+
+func (t *timeoutDatabase) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "UpdateItem")
+	defer cancel()
+	return t.next.UpdateItem(ctx, params, optFns...)
+}
+
+func (t *timeoutDatabase) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "Query")
+	defer cancel()
+	return t.next.Query(ctx, params, optFns...)
+}
+
+func (t *timeoutDatabase) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "DeleteItem")
+	defer cancel()
+	return t.next.DeleteItem(ctx, params, optFns...)
+}
+
+func (t *timeoutDatabase) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	ctx, cancel := t.withTimeout(ctx, "TransactWriteItems")
+	defer cancel()
+	return t.next.TransactWriteItems(ctx, params, optFns...)
+}
@@ -0,0 +1,86 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dba
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+)
+
+// SlowDynamoMock never answers, it only returns once the call's context ends
+type SlowDynamoMock struct {
+	OrgKeyDynamoMock
+	deadlines map[string]time.Duration
+}
+
+func (m *SlowDynamoMock) wait(ctx context.Context, op string) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		m.deadlines[op] = time.Until(deadline)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (m *SlowDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return nil, m.wait(ctx, "GetItem")
+}
+
+func (m *SlowDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return nil, m.wait(ctx, "Query")
+}
+
+func TestOperationsTimeOut(t *testing.T) {
+	dynMock := &SlowDynamoMock{deadlines: make(map[string]time.Duration)}
+	repo := (&DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: withTimeouts(dynMock, 10*time.Millisecond, map[string]time.Duration{"Query": 20 * time.Millisecond}),
+	}).ForOrg("org-1")
+
+	if _, err := repo.ReadProfile(context.Background(), ReadProfileFound); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected read to time out, got %v", err)
+	}
+	if _, err := repo.ListProfiles(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected list to time out, got %v", err)
+	}
+
+	if dynMock.deadlines["GetItem"] > 10*time.Millisecond || dynMock.deadlines["Query"] <= 10*time.Millisecond {
+		t.Fatalf("expected per operation timeouts, got %v", dynMock.deadlines)
+	}
+}
+
+func TestOperationsFollowCallerContext(t *testing.T) {
+	dynMock := &SlowDynamoMock{deadlines: make(map[string]time.Duration)}
+	repo := (&DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: withTimeouts(dynMock, 0, nil),
+	}).ForOrg("org-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.ReadProfile(ctx, ReadProfileFound); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the caller's cancellation, got %v", err)
+	}
+	if _, ok := dynMock.deadlines["GetItem"]; ok {
+		t.Fatal("expected no deadline when timeouts are off")
+	}
+}
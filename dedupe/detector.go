@@ -0,0 +1,165 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"sort"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// Reasons a pair of profiles is considered a duplicate
+const (
+	ReasonEmail      = "email"
+	ReasonNameAndDob = "legal_name_and_date_of_birth"
+	ReasonAddress    = "address"
+)
+
+// DefaultMinScore reports any pair matching on email or on legal name and
+// date of birth, a shared address alone is not enough
+const DefaultMinScore = 0.5
+
+const (
+	emailWeight      = 0.6
+	nameAndDobWeight = 0.5
+	addressWeight    = 0.2
+)
+
+// MaxBlockSize is the most profiles sharing a key that are compared pairwise.
+// A key shared by more, such as a team inbox or placeholder data, says little
+// about any one pair and would make the work quadratic, so it is skipped.
+const MaxBlockSize = 100
+
+type Candidate struct {
+	First   model.ProfileResponse
+	Second  model.ProfileResponse
+	Score   float64
+	Reasons []string
+}
+
+type pair struct{ i, j int }
+
+// FindCandidates returns the pairs of profiles scoring at least minScore,
+// highest score first. Profiles that were already merged are ignored. Only
+// profiles sharing a normalized email or legal name and date of birth are
+// compared, so the work grows with the number of collisions rather than with
+// every possible pair. A shared address only adds to the score of those pairs,
+// and keys shared by more than MaxBlockSize profiles are skipped.
+func FindCandidates(profiles []model.ProfileResponse, minScore float64) []Candidate {
+	live := make([]model.ProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		if !p.IsMerged() {
+			live = append(live, p)
+		}
+	}
+
+	blocks := make(map[string][]int)
+	for i, p := range live {
+		for _, key := range blockingKeys(p) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := make(map[pair]bool)
+	candidates := []Candidate{}
+	for _, members := range blocks {
+		if len(members) > MaxBlockSize {
+			continue
+		}
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				p := pair{members[a], members[b]}
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+
+				first, second := live[p.i], live[p.j]
+				// order within a pair must not depend on how profiles were listed
+				if second.UserId < first.UserId {
+					first, second = second, first
+				}
+
+				score, reasons := Score(first, second)
+				if score >= minScore {
+					candidates = append(candidates, Candidate{
+						First:   first,
+						Second:  second,
+						Score:   score,
+						Reasons: reasons,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Score != candidates[b].Score {
+			return candidates[a].Score > candidates[b].Score
+		}
+		if candidates[a].First.UserId != candidates[b].First.UserId {
+			return candidates[a].First.UserId < candidates[b].First.UserId
+		}
+		return candidates[a].Second.UserId < candidates[b].Second.UserId
+	})
+	return candidates
+}
+
+// Score rates how likely a and b belong to the same person, capped at 1
+func Score(a, b model.ProfileResponse) (float64, []string) {
+	var score float64
+	var reasons []string
+
+	if e := NormalizeEmail(a.Email); e != "" && e == NormalizeEmail(b.Email) {
+		score += emailWeight
+		reasons = append(reasons, ReasonEmail)
+	}
+	if k := nameAndDob(a); k != "" && k == nameAndDob(b) {
+		score += nameAndDobWeight
+		reasons = append(reasons, ReasonNameAndDob)
+	}
+	if addr := NormalizeText(a.Address); addr != "" && addr == NormalizeText(b.Address) {
+		score += addressWeight
+		reasons = append(reasons, ReasonAddress)
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+func nameAndDob(p model.ProfileResponse) string {
+	name, dob := NormalizeText(p.LegalName), NormalizeText(p.DateOfBirth)
+	if name == "" || dob == "" {
+		return ""
+	}
+	return name + "|" + dob
+}
+
+// blockingKeys leaves out the address, which households and offices share
+// and which cannot reach DefaultMinScore on its own
+func blockingKeys(p model.ProfileResponse) []string {
+	var keys []string
+	if e := NormalizeEmail(p.Email); e != "" {
+		keys = append(keys, ReasonEmail+":"+e)
+	}
+	if k := nameAndDob(p); k != "" {
+		keys = append(keys, ReasonNameAndDob+":"+k)
+	}
+	return keys
+}
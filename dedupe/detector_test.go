@@ -0,0 +1,128 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedupe
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := map[string]string{
+		" Jane.Doe+work@Example.com ": "jane.doe@example.com",
+		"jane.doe@example.com":        "jane.doe@example.com",
+		"not-an-email":                "not-an-email",
+	}
+	for in, want := range cases {
+		if got := NormalizeEmail(in); got != want {
+			t.Fatalf("NormalizeEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	if got := NormalizeText("  1 Main St.,  Springfield "); got != "1 main st springfield" {
+		t.Fatalf("unexpected normalized text %q", got)
+	}
+	if NormalizeText("10/22/2003") != NormalizeText("10-22-2003") {
+		t.Fatal("expected date separators to be ignored")
+	}
+}
+
+func TestFindCandidates(t *testing.T) {
+	profiles := []model.ProfileResponse{
+		{UserId: "a", Email: "jane@personal.com", LegalName: "Jane Doe", DateOfBirth: "10/22/2003", Address: "1 Main St"},
+		{UserId: "b", Email: "jane.doe@bank.com", LegalName: "jane  doe", DateOfBirth: "10-22-2003", Address: "1 Main St."},
+		{UserId: "c", Email: "JANE+x@personal.com", LegalName: "J Doe"},
+		{UserId: "d", Email: "bob@bank.com", Address: "1 main st"},
+		{UserId: "e", Email: "jane@personal.com", MergedInto: "a"},
+	}
+
+	candidates := FindCandidates(profiles, DefaultMinScore)
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %+v", candidates)
+	}
+
+	work := candidates[0]
+	if work.First.UserId != "a" || work.Second.UserId != "b" || work.Score <= DefaultMinScore {
+		t.Fatalf("expected the personal and work signups first, got %+v", work)
+	}
+	if len(work.Reasons) != 2 || work.Reasons[0] != ReasonNameAndDob || work.Reasons[1] != ReasonAddress {
+		t.Fatalf("unexpected reasons %v", work.Reasons)
+	}
+
+	email := candidates[1]
+	if email.First.UserId != "a" || email.Second.UserId != "c" || email.Reasons[0] != ReasonEmail {
+		t.Fatalf("expected the email match second, got %+v", email)
+	}
+
+	// a shared address alone never makes profiles comparable
+	if all := FindCandidates(profiles, addressWeight); len(all) != 2 {
+		t.Fatalf("expected no address only matches with a lower threshold, got %d", len(all))
+	}
+}
+
+func TestFindCandidatesSkipsLargeBlocks(t *testing.T) {
+	var profiles []model.ProfileResponse
+	for i := 0; i <= MaxBlockSize; i++ {
+		profiles = append(profiles, model.ProfileResponse{UserId: fmt.Sprintf("u%03d", i), Email: "info@example.com"})
+	}
+	profiles[0].LegalName, profiles[0].DateOfBirth = "Jane Doe", "10/22/2003"
+	profiles[1].LegalName, profiles[1].DateOfBirth = "Jane Doe", "10/22/2003"
+
+	candidates := FindCandidates(profiles, DefaultMinScore)
+	if len(candidates) != 1 || candidates[0].First.UserId != "u000" || candidates[0].Second.UserId != "u001" {
+		t.Fatalf("expected only the name and date of birth match, got %d candidates", len(candidates))
+	}
+
+	if candidates = FindCandidates(profiles[:MaxBlockSize], DefaultMinScore); len(candidates) != MaxBlockSize*(MaxBlockSize-1)/2 {
+		t.Fatalf("expected a block at the limit to be compared, got %d candidates", len(candidates))
+	}
+}
+
+func TestFindCandidatesIgnoresListingOrder(t *testing.T) {
+	profiles := []model.ProfileResponse{
+		{UserId: "a", Email: "jane@personal.com", LegalName: "Jane Doe", DateOfBirth: "10/22/2003"},
+		{UserId: "b", Email: "jane.doe@bank.com", LegalName: "Jane Doe", DateOfBirth: "10/22/2003"},
+		{UserId: "c", Email: "jane@personal.com"},
+		{UserId: "d", Email: "bob@bank.com"},
+	}
+	want := FindCandidates(profiles, DefaultMinScore)
+
+	r := rand.New(rand.NewSource(1))
+	for run := 0; run < 20; run++ {
+		shuffled := append([]model.ProfileResponse(nil), profiles...)
+		r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		got := FindCandidates(shuffled, DefaultMinScore)
+		if len(got) != len(want) {
+			t.Fatalf("expected %d candidates, got %d", len(want), len(got))
+		}
+		for i := range want {
+			if got[i].First.UserId != want[i].First.UserId || got[i].Second.UserId != want[i].Second.UserId {
+				t.Fatalf("pair %d changed with listing order: %s-%s, want %s-%s", i,
+					got[i].First.UserId, got[i].Second.UserId, want[i].First.UserId, want[i].Second.UserId)
+			}
+			if got[i].First.UserId > got[i].Second.UserId {
+				t.Fatalf("expected pair %d ordered by user id, got %s-%s", i, got[i].First.UserId, got[i].Second.UserId)
+			}
+		}
+	}
+}
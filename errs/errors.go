@@ -0,0 +1,153 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package errs is the service's canonical error model. Domain packages
+// return *Error values, and an interceptor turns them into gRPC statuses with
+// google.rpc details that the gateway renders as a stable JSON envelope.
+package errs
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Domain is reported in the ErrorInfo of every error
+const Domain = "usermgr.ib.coinbase.com"
+
+type Kind int
+
+const (
+	Internal Kind = iota
+	InvalidArgument
+	Unauthenticated
+	PermissionDenied
+	NotFound
+	AlreadyExists
+	// Conflict is a write that lost against the current state of a resource
+	Conflict
+	// FailedPrecondition is a request the resource's state does not allow
+	FailedPrecondition
+	// Throttled means a dependency such as DynamoDB is shedding load
+	Throttled
+	Unavailable
+	// ResourceExhausted means the caller used up its own quota
+	ResourceExhausted
+)
+
+var kindCodes = map[Kind]codes.Code{
+	Internal:           codes.Internal,
+	InvalidArgument:    codes.InvalidArgument,
+	Unauthenticated:    codes.Unauthenticated,
+	PermissionDenied:   codes.PermissionDenied,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.AlreadyExists,
+	Conflict:           codes.Aborted,
+	FailedPrecondition: codes.FailedPrecondition,
+	Throttled:          codes.Unavailable,
+	Unavailable:        codes.Unavailable,
+	ResourceExhausted:  codes.ResourceExhausted,
+}
+
+func (k Kind) Code() codes.Code {
+	if code, ok := kindCodes[k]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+// Error is a domain error. Reason and Message are sent to clients, the
+// wrapped cause is only logged.
+type Error struct {
+	Kind Kind
+	// Reason is a stable UPPER_SNAKE_CASE identifier clients can switch on
+	Reason   string
+	Message  string
+	Metadata map[string]string
+	// RetryAfter tells clients how long to wait before trying again
+	RetryAfter time.Duration
+	cause      error
+	// sentinel is the package level error this one was derived from
+	sentinel *Error
+}
+
+// New returns an error meant to be declared once as a package sentinel
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// Wrap returns e with cause attached for logs. The result still matches e
+// with errors.Is.
+func (e *Error) Wrap(cause error) *Error {
+	wrapped := e.copy()
+	wrapped.cause = cause
+	return wrapped
+}
+
+func (e *Error) copy() *Error {
+	return &Error{
+		Kind:       e.Kind,
+		Reason:     e.Reason,
+		Message:    e.Message,
+		Metadata:   e.Metadata,
+		RetryAfter: e.RetryAfter,
+		cause:      e.cause,
+		sentinel:   e.origin(),
+	}
+}
+
+func (e *Error) origin() *Error {
+	if e.sentinel != nil {
+		return e.sentinel
+	}
+	return e
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t == e.origin()
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.cause)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// With returns a copy of e carrying extra ErrorInfo metadata
+func (e *Error) With(key, value string) *Error {
+	metadata := make(map[string]string, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	withMetadata := e.copy()
+	withMetadata.Metadata = metadata
+	return withMetadata
+}
+
+// WithRetryAfter returns a copy of e telling clients to retry after d
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	withRetry := e.copy()
+	withRetry.RetryAfter = d
+	return withRetry
+}
@@ -0,0 +1,175 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTestNotFound = New(NotFound, "THING_NOT_FOUND", "thing not found")
+
+func errorInfo(t *testing.T, s *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("expected ErrorInfo on %v", s)
+	return nil
+}
+
+func TestSentinelMatching(t *testing.T) {
+	wrapped := fmt.Errorf("handler could not read: %w", errTestNotFound.Wrap(errors.New("dynamodb said no")))
+
+	if !errors.Is(wrapped, errTestNotFound) {
+		t.Fatal("expected wrapped sentinel to match")
+	}
+	if errors.Is(wrapped, New(NotFound, "THING_NOT_FOUND", "thing not found")) {
+		t.Fatal("expected a different sentinel with the same reason not to match")
+	}
+	if !errors.Is(errTestNotFound.With("thingId", "1"), errTestNotFound) {
+		t.Fatal("expected sentinel with metadata to match")
+	}
+}
+
+func TestToStatusDomainError(t *testing.T) {
+	err := fmt.Errorf("handler could not read: %w", errTestNotFound.With("thingId", "1").Wrap(errors.New("table Profile is gone")))
+
+	s := ToStatus(err)
+	if s.Code() != codes.NotFound || s.Message() != "thing not found" {
+		t.Fatalf("unexpected status: %v", s)
+	}
+	info := errorInfo(t, s)
+	if info.Reason != "THING_NOT_FOUND" || info.Domain != Domain || info.Metadata["thingId"] != "1" {
+		t.Fatalf("unexpected error info: %v", info)
+	}
+
+	if status.Code(errTestNotFound) != codes.NotFound {
+		t.Fatal("expected an unwrapped domain error to carry its code")
+	}
+}
+
+func TestToStatusHidesInternalErrors(t *testing.T) {
+	s := ToStatus(errors.New("dynamodb could not getItem: table Profile is gone"))
+	if s.Code() != codes.Internal || s.Message() != "internal error" {
+		t.Fatalf("expected opaque internal error, got %v", s)
+	}
+
+	s = ToStatus(status.Error(codes.PermissionDenied, "not yours"))
+	if s.Code() != codes.PermissionDenied || s.Message() != "not yours" {
+		t.Fatalf("expected status to pass through, got %v", s)
+	}
+
+	s = ToStatus(fmt.Errorf("query: %w", context.DeadlineExceeded))
+	if s.Code() != codes.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", s)
+	}
+}
+
+func TestToStatusValidation(t *testing.T) {
+	err := fmt.Errorf("profile handler could not validate request: %w", (&profile.ReadProfileRequest{Id: "short"}).ValidateAll())
+
+	s := ToStatus(err)
+	if s.Code() != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", s)
+	}
+	for _, d := range s.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			if len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "Id" {
+				t.Fatalf("unexpected violations: %v", br.FieldViolations)
+			}
+			return
+		}
+	}
+	t.Fatal("expected BadRequest details")
+}
+
+func TestGatewayErrorHandler(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		reason string
+	}{
+		{ToStatus(errTestNotFound).Err(), http.StatusNotFound, "THING_NOT_FOUND"},
+		{ToStatus(New(Unauthenticated, "INVALID_TOKEN", "invalid token")).Err(), http.StatusUnauthorized, "INVALID_TOKEN"},
+		{ToStatus(New(Conflict, "MERGE_CONFLICT", "already merged")).Err(), http.StatusConflict, "MERGE_CONFLICT"},
+		{ToStatus(New(Throttled, "BACKEND_THROTTLED", "busy")).Err(), http.StatusServiceUnavailable, "BACKEND_THROTTLED"},
+		{errors.New("boom"), http.StatusInternalServerError, ""},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		GatewayErrorHandler(context.Background(), nil, nil, w, httptest.NewRequest(http.MethodGet, "/v1/profile/1", nil), c.err)
+
+		if w.Code != c.status || w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%v: unexpected response %d %s", c.err, w.Code, w.Header().Get("Content-Type"))
+			continue
+		}
+		var env Envelope
+		if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+			t.Fatalf("could not decode envelope: %v", err)
+		}
+		if env.Error.Code != c.status || env.Error.Reason != c.reason || env.Error.Status == "" || env.Error.Message == "" {
+			t.Errorf("%v: unexpected envelope %+v", c.err, env)
+		}
+		if c.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+			t.Errorf("expected WWW-Authenticate header")
+		}
+	}
+}
+
+func TestGatewayRetryAfter(t *testing.T) {
+	limited := New(ResourceExhausted, "RATE_LIMITED", "too many requests").WithRetryAfter(1500 * time.Millisecond)
+
+	s := ToStatus(fmt.Errorf("interceptor: %w", limited))
+	if s.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected resource exhausted, got %v", s.Code())
+	}
+	if d, ok := RetryAfter(s); !ok || d != 1500*time.Millisecond {
+		t.Fatalf("expected retry info of 1.5s, got %v %v", d, ok)
+	}
+
+	w := httptest.NewRecorder()
+	GatewayErrorHandler(context.Background(), nil, nil, w, httptest.NewRequest(http.MethodPut, "/v1/profile/1", nil), s.Err())
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("expected Retry-After rounded up to 2, got %q", got)
+	}
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Error.Reason != "RATE_LIMITED" || env.Error.RetryAfterSeconds != 2 {
+		t.Fatalf("unexpected envelope %+v", env)
+	}
+}
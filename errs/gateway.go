@@ -0,0 +1,121 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Envelope is the body of every error response from the gateway
+type Envelope struct {
+	Error EnvelopeError `json:"error"`
+}
+
+type EnvelopeError struct {
+	// Code is the HTTP status
+	Code int `json:"code"`
+	// Status is the gRPC code name, e.g. NOT_FOUND
+	Status          string            `json:"status"`
+	Reason          string            `json:"reason,omitempty"`
+	Message         string            `json:"message"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	FieldViolations []FieldViolation  `json:"fieldViolations,omitempty"`
+	// RetryAfterSeconds mirrors the Retry-After header
+	RetryAfterSeconds int64 `json:"retryAfterSeconds,omitempty"`
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// NewEnvelope builds the error envelope for a status
+func NewEnvelope(s *status.Status, httpStatus int) Envelope {
+	body := EnvelopeError{
+		Code:    httpStatus,
+		Status:  code.Code_name[int32(s.Code())],
+		Message: s.Message(),
+	}
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			body.Reason = d.Reason
+			body.Metadata = d.Metadata
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				body.FieldViolations = append(body.FieldViolations, FieldViolation{Field: v.Field, Description: v.Description})
+			}
+		case *errdetails.RetryInfo:
+			body.RetryAfterSeconds = retryAfterSeconds(d.RetryDelay.AsDuration())
+		}
+	}
+
+	return Envelope{Error: body}
+}
+
+// GatewayErrorHandler renders errors as an Envelope instead of the gateway's
+// default status proto, so the JSON shape does not depend on the marshaler
+func GatewayErrorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	httpStatus := 0
+	var customStatus *runtime.HTTPStatusError
+	if errors.As(err, &customStatus) {
+		httpStatus = customStatus.HTTPStatus
+		err = customStatus.Err
+	}
+
+	s := ToStatus(err)
+	if httpStatus == 0 {
+		httpStatus = runtime.HTTPStatusFromCode(s.Code())
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+	if s.Code() == codes.Unauthenticated {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	if retryAfter, ok := RetryAfter(s); ok {
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+	}
+
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(NewEnvelope(s, httpStatus)); err != nil {
+		log.Debugf("could not write error response: %v", err)
+	}
+}
+
+// retryAfterSeconds rounds up, so a client honoring it never retries early
+func retryAfterSeconds(d time.Duration) int64 {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
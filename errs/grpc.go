@@ -0,0 +1,153 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fieldError is implemented by every protoc-gen-validate ValidationError
+type fieldError interface {
+	Field() string
+	Reason() string
+}
+
+// multiError is implemented by the errors ValidateAll returns
+type multiError interface {
+	AllErrors() []error
+}
+
+// ToStatus maps any error returned by a handler to the status sent to the
+// client. Statuses built by the handler pass through, unclassified errors
+// become a bare Internal so no internal detail leaks.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainStatus(domainErr)
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		return s
+	}
+
+	if violations := fieldViolations(err); len(violations) > 0 {
+		s := status.New(codes.InvalidArgument, "request is invalid")
+		return withDetails(s,
+			&errdetails.ErrorInfo{Reason: "INVALID_ARGUMENT", Domain: Domain},
+			&errdetails.BadRequest{FieldViolations: violations},
+		)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, "request timed out")
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, "request was canceled")
+	}
+
+	return status.New(codes.Internal, "internal error")
+}
+
+// GRPCStatus lets status.Code and grpc itself understand an *Error that is
+// returned without being wrapped
+func (e *Error) GRPCStatus() *status.Status {
+	return domainStatus(e)
+}
+
+func domainStatus(domainErr *Error) *status.Status {
+	s := status.New(domainErr.Kind.Code(), domainErr.Message)
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{
+		Reason:   domainErr.Reason,
+		Domain:   Domain,
+		Metadata: domainErr.Metadata,
+	}}
+	if domainErr.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(domainErr.RetryAfter)})
+	}
+	return withDetails(s, details...)
+}
+
+// RetryAfter returns the delay carried in the RetryInfo of a status, if any
+func RetryAfter(s *status.Status) (time.Duration, bool) {
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			return info.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	var multi multiError
+	if errors.As(err, &multi) {
+		for _, e := range multi.AllErrors() {
+			if fe, ok := e.(fieldError); ok {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: fe.Field(), Description: fe.Reason()})
+			}
+		}
+		return violations
+	}
+
+	var single fieldError
+	if errors.As(err, &single) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: single.Field(), Description: single.Reason()})
+	}
+	return violations
+}
+
+func withDetails(s *status.Status, details ...protoiface.MessageV1) *status.Status {
+	detailed, err := s.WithDetails(details...)
+	if err != nil {
+		return s
+	}
+	return detailed
+}
+
+// UnaryServerInterceptor converts handler errors with ToStatus. It sits
+// outside the auth middleware so authentication failures are mapped too.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		s := ToStatus(err)
+		if s.Code() == codes.Internal || s.Code() == codes.Unavailable {
+			log.WarnfCtx(ctx, "request failed: %s - %v", info.FullMethod, err)
+		} else {
+			log.DebugfCtx(ctx, "request rejected: %s - %v", info.FullMethod, err)
+		}
+		return resp, s.Err()
+	}
+}
@@ -0,0 +1,135 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultApiKeyExpiryDays = 90
+
+func (o *ProfileServer) CreateApiKey(ctx context.Context, req *profile.CreateApiKeyRequest) (*profile.CreateApiKeyResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("api key handler could not validate request: %w", err)
+	}
+
+	// keys can only be minted by a signed in human, never by another key
+	if authedUser.ApiKeyId != "" {
+		return nil, status.Error(codes.PermissionDenied, "api keys cannot create other api keys")
+	}
+
+	plaintext, keyId, hash, err := auth.GenerateApiKey()
+	if err != nil {
+		return nil, fmt.Errorf("api key handler could not generate key: %w", err)
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = defaultApiKeyExpiryDays
+	}
+	now := time.Now().UTC()
+
+	log.DebugfCtx(ctx, "creating api key: %s - %s", authedUser.Id, keyId)
+	key, err := dba.Repo.CreateApiKey(ctx, model.ApiKey{
+		KeyId:      keyId,
+		UserId:     authedUser.Id,
+		OrgId:      authedUser.OrgId,
+		Email:      authedUser.Email,
+		Name:       req.Name,
+		Prefix:     auth.ApiKeyDisplayPrefix(keyId),
+		SecretHash: hash,
+		Scopes:     req.Scopes,
+		CreatedAt:  now,
+		ExpiresAt:  now.AddDate(0, 0, int(days)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("api key handler could not create key: %w", err)
+	}
+
+	return &profile.CreateApiKeyResponse{
+		ApiKey: conversions.ConvertApiKeyToProto(key),
+		Secret: plaintext,
+	}, nil
+}
+
+func (o *ProfileServer) ListApiKeys(ctx context.Context, req *profile.ListApiKeysRequest) (*profile.ListApiKeysResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "listing api keys: %s", authedUser.Id)
+	keys, err := dba.Repo.ListApiKeys(ctx, authedUser.Id)
+	if err != nil {
+		return nil, fmt.Errorf("api key handler could not list keys: %w", err)
+	}
+
+	return &profile.ListApiKeysResponse{ApiKeys: conversions.ConvertApiKeysToProto(keys)}, nil
+}
+
+func (o *ProfileServer) RevokeApiKey(ctx context.Context, req *profile.RevokeApiKeyRequest) (*profile.RevokeApiKeyResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("api key handler could not validate request: %w", err)
+	}
+
+	log.DebugfCtx(ctx, "revoking api key: %s - %s", authedUser.Id, req.KeyId)
+	key, err := dba.Repo.RevokeApiKey(ctx, authedUser.Id, req.KeyId)
+	if err != nil {
+		return nil, fmt.Errorf("api key handler could not revoke key: %w", err)
+	}
+
+	return &profile.RevokeApiKeyResponse{ApiKey: conversions.ConvertApiKeyToProto(key)}, nil
+}
+
+// revokeApiKeys revokes every key of userId that is still usable
+func revokeApiKeys(ctx context.Context, userId string) error {
+	keys, err := dba.Repo.ListApiKeys(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("could not list api keys: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.RevokedAt != nil {
+			continue
+		}
+		if _, err := dba.Repo.RevokeApiKey(ctx, userId, key.KeyId); err != nil && !errors.Is(err, dba.ErrApiKeyRevoked) {
+			return fmt.Errorf("could not revoke api key %s: %w", key.KeyId, err)
+		}
+	}
+	return nil
+}
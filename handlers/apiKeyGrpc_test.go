@@ -0,0 +1,95 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func setupApiKeyRepo() {
+	app := config.AppConfig{
+		ApiKeyTableName: "ApiKey",
+	}
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &app,
+		Svc: new(DynamoMock),
+	})
+}
+
+func TestCreateApiKeyHandler(t *testing.T) {
+	setupApiKeyRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", Email: "b.ross@coinbase.com"})
+
+	ps := ProfileServer{}
+	resp, err := ps.CreateApiKey(ctx, &profile.CreateApiKeyRequest{
+		Name:   "reporting script",
+		Scopes: []string{auth.ScopeProfileRead},
+	})
+	if err != nil {
+		t.Fatalf("unexpected create api key error: %v", err)
+	}
+
+	keyId, err := auth.ParseApiKey(resp.Secret)
+	if err != nil {
+		t.Fatalf("expected a well formed key: %v", err)
+	}
+	if keyId != resp.ApiKey.KeyId || resp.ApiKey.Prefix != auth.ApiKeyDisplayPrefix(keyId) {
+		t.Fatal("expected key metadata to match the issued key")
+	}
+
+	days := resp.ApiKey.ExpiresAt.AsTime().Sub(resp.ApiKey.CreatedAt.AsTime()).Hours() / 24
+	if days != defaultApiKeyExpiryDays {
+		t.Fatalf("expected default expiry, got %v days", days)
+	}
+}
+
+func TestCreateApiKeyHandlerInvalidScope(t *testing.T) {
+	setupApiKeyRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	ps := ProfileServer{}
+	_, err := ps.CreateApiKey(ctx, &profile.CreateApiKeyRequest{
+		Name:   "admin script",
+		Scopes: []string{"admin:everything"},
+	})
+	if err == nil {
+		t.Fatal("expected validation error for unknown scope")
+	}
+}
+
+func TestCreateApiKeyHandlerFromApiKey(t *testing.T) {
+	setupApiKeyRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", ApiKeyId: "0123456789abcdef"})
+
+	ps := ProfileServer{}
+	_, err := ps.CreateApiKey(ctx, &profile.CreateApiKeyRequest{
+		Name:   "nested key",
+		Scopes: []string{auth.ScopeProfileRead},
+	})
+	if errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+}
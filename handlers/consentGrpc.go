@@ -0,0 +1,118 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (o *ProfileServer) RecordConsent(ctx context.Context, req *profile.RecordConsentRequest) (*profile.RecordConsentResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("consent handler could not validate request: %w", err)
+	}
+
+	client := auth.ClientInfoFromContext(ctx)
+
+	log.DebugfCtx(ctx, "recording consent: %s - %s %s", authedUser.Id, req.DocumentType, req.DocumentVersion)
+	consent, err := dba.Repo.RecordConsent(ctx, model.Consent{
+		UserId:          authedUser.Id,
+		DocumentType:    req.DocumentType,
+		DocumentVersion: req.DocumentVersion,
+		IpAddress:       client.IpAddress,
+		UserAgent:       client.UserAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("consent handler could not record consent: %w", err)
+	}
+
+	return &profile.RecordConsentResponse{Consent: conversions.ConvertConsentToProto(consent)}, nil
+}
+
+func (o *ProfileServer) ListConsents(ctx context.Context, req *profile.ListConsentsRequest) (*profile.ListConsentsResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "listing consents: %s", authedUser.Id)
+	consents, err := dba.Repo.ListConsents(ctx, authedUser.Id)
+	if err != nil {
+		return nil, fmt.Errorf("consent handler could not list consents: %w", err)
+	}
+
+	return &profile.ListConsentsResponse{Consents: conversions.ConvertConsentsToProto(consents)}, nil
+}
+
+func (o *ProfileServer) WithdrawConsent(ctx context.Context, req *profile.WithdrawConsentRequest) (*profile.WithdrawConsentResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("consent handler could not validate request: %w", err)
+	}
+
+	log.DebugfCtx(ctx, "withdrawing consent: %s - %s", authedUser.Id, req.ConsentId)
+	consent, err := dba.Repo.WithdrawConsent(ctx, authedUser.Id, req.ConsentId)
+	if err != nil {
+		return nil, fmt.Errorf("consent handler could not withdraw consent: %w", err)
+	}
+
+	return &profile.WithdrawConsentResponse{Consent: conversions.ConvertConsentToProto(consent)}, nil
+}
+
+// requireConsents fails with FailedPrecondition until the user has an active
+// consent for the current version of every required document
+func (o *ProfileServer) requireConsents(ctx context.Context, userId string) error {
+	if len(o.RequiredConsents) == 0 {
+		return nil
+	}
+
+	consents, err := dba.Repo.ListConsents(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("could not check required consents: %w", err)
+	}
+
+	accepted := make(map[string]bool)
+	for _, c := range consents {
+		if c.IsActive() && o.RequiredConsents[c.DocumentType] == c.DocumentVersion {
+			accepted[c.DocumentType] = true
+		}
+	}
+
+	for docType, version := range o.RequiredConsents {
+		if !accepted[docType] {
+			return status.Errorf(codes.FailedPrecondition, "consent required: %s version %s has not been accepted", docType, version)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func setupConsentRepo() {
+	app := config.AppConfig{
+		ProfileTableName: "Profile",
+		ConsentTableName: "Consent",
+	}
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &app,
+		Svc: new(DynamoMock),
+	})
+}
+
+// recordConsentAddress records a consent through the client info interceptor
+// and returns the address it was recorded from
+func recordConsentAddress(t *testing.T, peerIp, forwardedFor string) string {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIp), Port: 50000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+
+	proxies, _ := auth.ParseTrustedProxies("127.0.0.1")
+	ps := ProfileServer{}
+	resp, err := auth.ClientInfoInterceptor(proxies)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return ps.RecordConsent(ctx, &profile.RecordConsentRequest{DocumentType: "tos", DocumentVersion: "2023-01"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected record consent error: %v", err)
+	}
+	return resp.(*profile.RecordConsentResponse).Consent.IpAddress
+}
+
+func TestRecordConsentAddress(t *testing.T) {
+	setupConsentRepo()
+
+	if got := recordConsentAddress(t, "127.0.0.1", "203.0.113.9, 192.0.2.100"); got != "192.0.2.100" {
+		t.Fatalf("expected the client the gateway forwarded for, got %s", got)
+	}
+	if got := recordConsentAddress(t, "192.0.2.100", "203.0.113.9"); got != "192.0.2.100" {
+		t.Fatalf("expected a forged x-forwarded-for to be ignored, got %s", got)
+	}
+}
+
+func TestRecordConsentHandler(t *testing.T) {
+	setupConsentRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.100"), Port: 50000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("grpcgateway-user-agent", "Mozilla/5.0"))
+
+	ps := ProfileServer{}
+	resp, err := ps.RecordConsent(ctx, &profile.RecordConsentRequest{
+		DocumentType:    "tos",
+		DocumentVersion: "2023-01",
+	})
+	if err != nil {
+		t.Fatalf("unexpected record consent error: %v", err)
+	}
+
+	if resp.Consent.IpAddress != "192.0.2.100" {
+		t.Fatalf("expected the client ip, got %s", resp.Consent.IpAddress)
+	}
+	if resp.Consent.UserAgent != "Mozilla/5.0" {
+		t.Fatalf("expected user agent from gateway, got %s", resp.Consent.UserAgent)
+	}
+	if resp.Consent.UserId != "123" {
+		t.Fatal("expected consent to belong to the authed user")
+	}
+}
+
+func TestRecordConsentHandlerInvalid(t *testing.T) {
+	setupConsentRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	ps := ProfileServer{}
+	if _, err := ps.RecordConsent(ctx, &profile.RecordConsentRequest{}); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestListConsentsHandler(t *testing.T) {
+	setupConsentRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	ps := ProfileServer{}
+	resp, err := ps.ListConsents(ctx, &profile.ListConsentsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected list consents error: %v", err)
+	}
+
+	if len(resp.Consents) != 1 || resp.Consents[0].ConsentId != ConsentFound {
+		t.Fatalf("unexpected consents: %v", resp.Consents)
+	}
+}
+
+func TestWithdrawConsentHandler(t *testing.T) {
+	setupConsentRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	ps := ProfileServer{}
+	resp, err := ps.WithdrawConsent(ctx, &profile.WithdrawConsentRequest{ConsentId: ConsentFound})
+	if err != nil {
+		t.Fatalf("unexpected withdraw consent error: %v", err)
+	}
+
+	if resp.Consent.WithdrawnAt == nil {
+		t.Fatal("expected withdrawn at to be set")
+	}
+}
+
+func TestUpdateHandlerRequiresConsent(t *testing.T) {
+	setupConsentRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
+	req := &profile.UpdateProfileRequest{
+		Id:          UpdateProfile,
+		Name:        "Bob Ross",
+		Email:       "b.ross@coinbase.com",
+		LegalName:   "Bob Ross",
+		UserName:    "demo0",
+		Address:     "123 Happy Way",
+		DateOfBirth: "The best day",
+	}
+
+	ps := ProfileServer{RequiredConsents: map[string]string{"tos": "2023-02"}}
+	_, err := ps.UpdateProfile(ctx, req)
+	if errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected failed precondition for outdated consent, got %v", err)
+	}
+
+	ps = ProfileServer{RequiredConsents: map[string]string{"tos": "2023-01"}}
+	if _, err := ps.UpdateProfile(ctx, req); err != nil {
+		t.Fatalf("unexpected error with accepted consent: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/dedupe"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (o *ProfileServer) ListDuplicateCandidates(ctx context.Context, req *profile.ListDuplicateCandidatesRequest) (*profile.ListDuplicateCandidatesResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("duplicate handler could not validate request: %w", err)
+	}
+
+	repo, err := requireOrgAdmin(ctx, authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	minScore := req.MinScore
+	if minScore == 0 {
+		minScore = dedupe.DefaultMinScore
+	}
+
+	log.DebugfCtx(ctx, "listing duplicate candidates: %s - %v", authedUser.OrgId, minScore)
+	profiles, err := repo.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("duplicate handler could not list profiles: %w", err)
+	}
+
+	candidates := dedupe.FindCandidates(profiles, minScore)
+	return &profile.ListDuplicateCandidatesResponse{Candidates: conversions.ConvertDuplicateCandidatesToProto(candidates)}, nil
+}
+
+func (o *ProfileServer) MergeProfiles(ctx context.Context, req *profile.MergeProfilesRequest) (*profile.MergeProfilesResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("duplicate handler could not validate request: %w", err)
+	}
+
+	if req.SurvivorId == req.LoserId {
+		return nil, status.Error(codes.InvalidArgument, "cannot merge a profile into itself")
+	}
+
+	repo, err := requireOrgAdmin(ctx, authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	survivor, err := readMergeable(ctx, repo, req.SurvivorId)
+	if err != nil {
+		return nil, err
+	}
+	loser, err := readMergeable(ctx, repo, req.LoserId)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeFields(survivor, loser, req.Fields)
+
+	log.InfofCtx(ctx, "merging profiles: %s - %s into %s - %v", authedUser.OrgId, loser.UserId, survivor.UserId, req.Fields)
+	body, err := repo.MergeProfiles(ctx, survivor.UserId, loser.UserId, merged)
+	if err != nil {
+		return nil, fmt.Errorf("duplicate handler could not merge profiles: %w", err)
+	}
+	o.syncAttributes(ctx, survivor, body)
+
+	details := map[string]string{
+		"survivorId": survivor.UserId,
+		"loserId":    loser.UserId,
+		"fields":     strings.Join(req.Fields, ","),
+	}
+	for _, id := range []string{survivor.UserId, loser.UserId} {
+		if err := recordHistory(ctx, authedUser, authedUser.OrgId, id, model.HistoryProfileMerged, details); err != nil {
+			log.WarnfCtx(ctx, "could not record profile merge: %v", err)
+		}
+	}
+
+	response := conversions.ConvertReadProfileToProto(body)
+	return &profile.MergeProfilesResponse{Survivor: &response}, nil
+}
+
+func readMergeable(ctx context.Context, repo dba.Repository, id string) (model.ProfileResponse, error) {
+	p, err := repo.ReadProfile(ctx, id)
+	if err != nil {
+		return p, fmt.Errorf("duplicate handler could not read profile: %w", err)
+	}
+	if p.IsMerged() {
+		return p, status.Errorf(codes.FailedPrecondition, "profile %s was already merged", id)
+	}
+	return p, nil
+}
+
+// mergeFields starts from the survivor and copies the chosen fields over
+// from the loser
+func mergeFields(survivor, loser model.ProfileResponse, fields []string) model.UpdateProfileRequest {
+	merged := model.UpdateProfileRequest(survivor)
+	for _, field := range fields {
+		switch field {
+		case "email":
+			merged.Email = loser.Email
+		case "name":
+			merged.Name = loser.Name
+		case "legal_name":
+			merged.LegalName = loser.LegalName
+		case "user_name":
+			merged.UserName = loser.UserName
+		case "address":
+			merged.Address = loser.Address
+		case "date_of_birth":
+			merged.DateOfBirth = loser.DateOfBirth
+		}
+	}
+	return merged
+}
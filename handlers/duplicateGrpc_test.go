@@ -0,0 +1,129 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/coinbase-samples/ib-usermgr-go/dedupe"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	personalId = "1A2B3C4D-0000-4000-8000-000000000001"
+	workId     = "1A2B3C4D-0000-4000-8000-000000000002"
+)
+
+func seedDuplicates(t *testing.T, dynMock *OrgDynamoMock) {
+	t.Helper()
+	for _, p := range []model.UpdateProfileRequest{
+		{OrgId: "org-1", UserId: personalId, Email: "jane@personal.com", LegalName: "Jane Doe", DateOfBirth: "10/22/2003", Address: "1 Main St"},
+		{OrgId: "org-1", UserId: workId, Email: "jane.doe@bank.com", LegalName: "Jane Doe", DateOfBirth: "10-22-2003", Address: "2 Bank Plaza"},
+	} {
+		item, err := attributevalue.MarshalMap(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dynMock.profiles["org-1/"+p.UserId] = item
+	}
+}
+
+func TestListDuplicateCandidates(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	seedDuplicates(t, dynMock)
+	ps := ProfileServer{}
+
+	resp, err := ps.ListDuplicateCandidates(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.ListDuplicateCandidatesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Candidates) != 1 {
+		t.Fatalf("expected one candidate pair, got %v", resp.Candidates)
+	}
+	c := resp.Candidates[0]
+	if c.First.UserId != personalId || c.Second.UserId != workId || c.Reasons[0] != dedupe.ReasonNameAndDob {
+		t.Fatalf("unexpected candidate: %v", c)
+	}
+
+	if _, err = ps.ListDuplicateCandidates(orgCtx(model.User{Id: personalId, OrgId: "org-1"}), &profile.ListDuplicateCandidatesRequest{}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a non admin, got %v", err)
+	}
+}
+
+func TestMergeProfiles(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	seedDuplicates(t, dynMock)
+	ps := ProfileServer{}
+	ctx := orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"})
+
+	resp, err := ps.MergeProfiles(ctx, &profile.MergeProfilesRequest{
+		SurvivorId: workId,
+		LoserId:    personalId,
+		Fields:     []string{"address"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected merge error: %v", err)
+	}
+	if resp.Survivor.UserId != workId || resp.Survivor.Email != "jane.doe@bank.com" || resp.Survivor.Address != "1 Main St" {
+		t.Fatalf("unexpected survivor: %v", resp.Survivor)
+	}
+
+	loser, err := ps.ReadProfile(orgCtx(model.User{Id: personalId, OrgId: "org-1"}), &profile.ReadProfileRequest{Id: personalId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loser.MergedInto != workId {
+		t.Fatalf("expected the loser to point at the survivor, got %q", loser.MergedInto)
+	}
+
+	if len(dynMock.history) != 2 || attrS(dynMock.history[0], "Action") != model.HistoryProfileMerged {
+		t.Fatalf("expected the merge in both histories, got %v", dynMock.history)
+	}
+
+	if _, err = ps.MergeProfiles(ctx, &profile.MergeProfilesRequest{SurvivorId: workId, LoserId: personalId}); errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition merging twice, got %v", err)
+	}
+
+	candidates, err := ps.ListDuplicateCandidates(ctx, &profile.ListDuplicateCandidatesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates.Candidates) != 0 {
+		t.Fatalf("expected merged profiles to drop out of the candidates, got %v", candidates.Candidates)
+	}
+}
+
+func TestMergeProfilesValidation(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	seedDuplicates(t, dynMock)
+	ps := ProfileServer{}
+	ctx := orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"})
+
+	if _, err := ps.MergeProfiles(ctx, &profile.MergeProfilesRequest{SurvivorId: workId, LoserId: workId}); errs.ToStatus(err).Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument merging into itself, got %v", err)
+	}
+	if _, err := ps.MergeProfiles(ctx, &profile.MergeProfilesRequest{SurvivorId: workId, LoserId: orgInviteeId}); errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing loser, got %v", err)
+	}
+	if _, err := ps.MergeProfiles(ctx, &profile.MergeProfilesRequest{SurvivorId: workId, LoserId: personalId, Fields: []string{"roles"}}); err == nil {
+		t.Fatal("expected roles to be rejected as a merge field")
+	}
+}
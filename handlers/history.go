@@ -0,0 +1,38 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// recordHistory appends action to subjectId's audit trail, attributing it to
+// whoever really made the request
+func recordHistory(ctx context.Context, user model.User, orgId, subjectId, action string, details map[string]string) error {
+	_, err := dba.Repo.RecordHistory(ctx, model.HistoryEvent{
+		UserId:               subjectId,
+		OrgId:                orgId,
+		Action:               action,
+		ActorId:              user.Actor(),
+		ImpersonationGrantId: user.ImpersonationGrantId,
+		Details:              details,
+	})
+	return err
+}
@@ -0,0 +1,153 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultImpersonationMinutes = 15
+	// grants are kept around for a while after expiry so they stay listable
+	impersonationRetention = 30 * 24 * time.Hour
+)
+
+func (o *ProfileServer) StartImpersonation(ctx context.Context, req *profile.StartImpersonationRequest) (*profile.StartImpersonationResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("impersonation handler could not validate request: %w", err)
+	}
+
+	if err := o.requireImpersonator(authedUser); err != nil {
+		return nil, err
+	}
+	if req.SubjectId == authedUser.Id {
+		return nil, status.Error(codes.InvalidArgument, "cannot impersonate yourself")
+	}
+
+	subject, err := dba.Profiles.ForOrg(req.SubjectOrgId).ReadProfile(ctx, req.SubjectId)
+	if err != nil {
+		return nil, fmt.Errorf("impersonation handler could not read subject profile: %w", err)
+	}
+
+	minutes := req.DurationMinutes
+	if minutes == 0 {
+		minutes = defaultImpersonationMinutes
+	}
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Duration(minutes) * time.Minute)
+
+	grant := model.ImpersonationGrant{
+		GrantId:      uuid.New().String(),
+		ActorId:      authedUser.Id,
+		ActorEmail:   authedUser.Email,
+		SubjectId:    subject.UserId,
+		SubjectOrgId: req.SubjectOrgId,
+		SubjectEmail: subject.Email,
+		Reason:       req.Reason,
+		CreatedAt:    now,
+		ExpiresAt:    expiresAt,
+		Ttl:          expiresAt.Add(impersonationRetention).Unix(),
+	}
+
+	// the grant is only issued once the customer's history shows who asked for it
+	if err := recordHistory(ctx, authedUser, grant.SubjectOrgId, grant.SubjectId, model.HistoryImpersonationStarted, map[string]string{
+		"grantId": grant.GrantId,
+		"reason":  grant.Reason,
+	}); err != nil {
+		return nil, fmt.Errorf("impersonation handler could not record history: %w", err)
+	}
+
+	log.InfofCtx(ctx, "starting impersonation: %s - %s - %s", grant.ActorId, grant.SubjectId, grant.GrantId)
+	grant, err = dba.Repo.CreateImpersonationGrant(ctx, grant)
+	if err != nil {
+		return nil, fmt.Errorf("impersonation handler could not create grant: %w", err)
+	}
+
+	return &profile.StartImpersonationResponse{Grant: conversions.ConvertImpersonationGrantToProto(grant)}, nil
+}
+
+func (o *ProfileServer) ListImpersonationGrants(ctx context.Context, req *profile.ListImpersonationGrantsRequest) (*profile.ListImpersonationGrantsResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.requireImpersonator(authedUser); err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "listing impersonation grants: %s", authedUser.Id)
+	grants, err := dba.Repo.ListImpersonationGrants(ctx, authedUser.Id)
+	if err != nil {
+		return nil, fmt.Errorf("impersonation handler could not list grants: %w", err)
+	}
+
+	return &profile.ListImpersonationGrantsResponse{Grants: conversions.ConvertImpersonationGrantsToProto(grants)}, nil
+}
+
+func (o *ProfileServer) RevokeImpersonation(ctx context.Context, req *profile.RevokeImpersonationRequest) (*profile.RevokeImpersonationResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("impersonation handler could not validate request: %w", err)
+	}
+
+	if err := o.requireImpersonator(authedUser); err != nil {
+		return nil, err
+	}
+
+	log.InfofCtx(ctx, "revoking impersonation: %s - %s", authedUser.Id, req.GrantId)
+	grant, err := dba.Repo.RevokeImpersonationGrant(ctx, authedUser.Id, req.GrantId)
+	if err != nil {
+		return nil, fmt.Errorf("impersonation handler could not revoke grant: %w", err)
+	}
+
+	if err := recordHistory(ctx, authedUser, grant.SubjectOrgId, grant.SubjectId, model.HistoryImpersonationRevoked, map[string]string{
+		"grantId": grant.GrantId,
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not record impersonation revocation: %v", err)
+	}
+
+	return &profile.RevokeImpersonationResponse{Grant: conversions.ConvertImpersonationGrantToProto(grant)}, nil
+}
+
+// requireImpersonator only lets configured support staff, signed in as
+// themselves, manage impersonation grants
+func (o *ProfileServer) requireImpersonator(user model.User) error {
+	if user.ApiKeyId != "" || user.IsImpersonated() || !o.Impersonators[user.Id] {
+		return status.Error(codes.PermissionDenied, "caller is not allowed to impersonate")
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc/codes"
+)
+
+const supportId = "5E1B7F0A-2C3D-4E5F-9A8B-1C2D3E4F5A6B"
+
+type ImpersonationDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	grants  map[string]map[string]types.AttributeValue
+	history []map[string]types.AttributeValue
+}
+
+func (m *ImpersonationDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if *params.TableName == "Impersonation" {
+		return &dynamodb.GetItemOutput{Item: m.grants[attrS(params.Key, "GrantId")]}, nil
+	}
+	if attrS(params.Key, "UserId") != ReadProfileFound {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	return &dynamodb.GetItemOutput{
+		Item: map[string]types.AttributeValue{
+			"OrgId":  params.Key["OrgId"],
+			"UserId": params.Key["UserId"],
+			"Email":  &types.AttributeValueMemberS{Value: "customer@example.com"},
+		},
+	}, nil
+}
+
+func (m *ImpersonationDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	if *params.TableName == "History" {
+		m.history = append(m.history, params.Item)
+	} else {
+		m.grants[attrS(params.Item, "GrantId")] = params.Item
+	}
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	grant := m.grants[attrS(params.Key, "GrantId")]
+	if _, revoked := grant["RevokedAt"]; grant == nil || revoked || attrS(grant, "ActorId") != attrS(params.ExpressionAttributeValues, ":actorId") {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	grant["RevokedAt"] = params.ExpressionAttributeValues[":revokedAt"]
+	return &dynamodb.UpdateItemOutput{Attributes: grant}, nil
+}
+
+func (m *ImpersonationDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	out := &dynamodb.QueryOutput{}
+	for _, grant := range m.grants {
+		if attrS(grant, "ActorId") == attrS(params.ExpressionAttributeValues, ":actorId") {
+			out.Items = append(out.Items, grant)
+		}
+	}
+	return out, nil
+}
+
+func (m *ImpersonationDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *ImpersonationDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func setupImpersonationTest() *ImpersonationDynamoMock {
+	dynMock := &ImpersonationDynamoMock{grants: map[string]map[string]types.AttributeValue{}}
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{
+			ProfileTableName:       "Profile",
+			ImpersonationTableName: "Impersonation",
+			HistoryTableName:       "History",
+		},
+		Svc: dynMock,
+	})
+	return dynMock
+}
+
+func TestImpersonationGrantLifecycle(t *testing.T) {
+	dynMock := setupImpersonationTest()
+	ps := ProfileServer{Impersonators: map[string]bool{supportId: true}}
+	ctx := orgCtx(model.User{Id: supportId, Email: "support@example.com"})
+
+	started, err := ps.StartImpersonation(ctx, &profile.StartImpersonationRequest{
+		SubjectId:    ReadProfileFound,
+		SubjectOrgId: "org-1",
+		Reason:       "customer cannot see their address",
+	})
+	if err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	grant := started.Grant
+	if grant.ActorId != supportId || grant.SubjectId != ReadProfileFound || grant.SubjectOrgId != "org-1" {
+		t.Fatalf("unexpected grant: %v", grant)
+	}
+	if d := grant.ExpiresAt.AsTime().Sub(grant.CreatedAt.AsTime()); d.Minutes() != defaultImpersonationMinutes {
+		t.Fatalf("expected the default duration, got %v", d)
+	}
+
+	if len(dynMock.history) != 1 ||
+		attrS(dynMock.history[0], "UserId") != ReadProfileFound ||
+		attrS(dynMock.history[0], "ActorId") != supportId ||
+		attrS(dynMock.history[0], "Action") != model.HistoryImpersonationStarted {
+		t.Fatalf("expected the customer's history to record the grant, got %v", dynMock.history)
+	}
+
+	listed, err := ps.ListImpersonationGrants(ctx, &profile.ListImpersonationGrantsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed.Grants) != 1 || listed.Grants[0].GrantId != grant.GrantId {
+		t.Fatalf("unexpected grants: %v", listed.Grants)
+	}
+
+	revoked, err := ps.RevokeImpersonation(ctx, &profile.RevokeImpersonationRequest{GrantId: grant.GrantId})
+	if err != nil {
+		t.Fatalf("unexpected revoke error: %v", err)
+	}
+	if revoked.Grant.RevokedAt == nil {
+		t.Fatal("expected the grant to be revoked")
+	}
+	if _, err = ps.RevokeImpersonation(ctx, &profile.RevokeImpersonationRequest{GrantId: grant.GrantId}); errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound revoking twice, got %v", err)
+	}
+}
+
+func TestStartImpersonationDenied(t *testing.T) {
+	setupImpersonationTest()
+	ps := ProfileServer{Impersonators: map[string]bool{supportId: true}}
+	req := &profile.StartImpersonationRequest{
+		SubjectId:    ReadProfileFound,
+		SubjectOrgId: "org-1",
+		Reason:       "customer cannot see their address",
+	}
+
+	callers := []model.User{
+		{Id: "123"},
+		{Id: supportId, ApiKeyId: "0123456789abcdef"},
+		{Id: "123", ActorId: supportId, ImpersonationGrantId: "grant-1"},
+	}
+	for _, caller := range callers {
+		if _, err := ps.StartImpersonation(orgCtx(caller), req); errs.ToStatus(err).Code() != codes.PermissionDenied {
+			t.Fatalf("expected PermissionDenied for %+v, got %v", caller, err)
+		}
+	}
+
+	req.SubjectId = ReadProfileNotFound
+	if _, err := ps.StartImpersonation(orgCtx(model.User{Id: supportId}), req); errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound for a missing subject, got %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultInvitationExpiryHours = 7 * 24
+
+func (o *ProfileServer) InviteMember(ctx context.Context, req *profile.InviteMemberRequest) (*profile.InviteMemberResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("org handler could not validate request: %w", err)
+	}
+
+	if _, err := requireOrgAdmin(ctx, authedUser); err != nil {
+		return nil, err
+	}
+
+	invitationId := uuid.New().String()
+	token, hash, err := generateInvitationToken(invitationId)
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not generate invitation token: %w", err)
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultInvitationExpiryHours
+	}
+	now := time.Now().UTC()
+
+	log.DebugfCtx(ctx, "inviting member: %s - %s - %s", authedUser.OrgId, invitationId, req.Role)
+	invitation, err := dba.Repo.CreateInvitation(ctx, model.Invitation{
+		InvitationId: invitationId,
+		OrgId:        authedUser.OrgId,
+		Email:        req.Email,
+		Role:         req.Role,
+		TokenHash:    hash,
+		InvitedBy:    authedUser.Id,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Duration(hours) * time.Hour),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not create invitation: %w", err)
+	}
+
+	return &profile.InviteMemberResponse{
+		Invitation: conversions.ConvertInvitationToProto(invitation),
+		Token:      token,
+	}, nil
+}
+
+func (o *ProfileServer) AcceptInvitation(ctx context.Context, req *profile.AcceptInvitationRequest) (*profile.AcceptInvitationResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("org handler could not validate request: %w", err)
+	}
+
+	if authedUser.ApiKeyId != "" {
+		return nil, status.Error(codes.PermissionDenied, "invitations must be accepted by a signed in user")
+	}
+
+	invitationId, _, ok := strings.Cut(req.Token, ".")
+	if !ok {
+		return nil, dba.ErrInvitationNotFound
+	}
+
+	invitation, err := dba.Repo.GetInvitation(ctx, invitationId)
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not read invitation: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashInvitationToken(req.Token)), []byte(invitation.TokenHash)) != 1 {
+		return nil, dba.ErrInvitationNotFound
+	}
+	if !invitation.IsPending(time.Now()) {
+		return nil, dba.ErrInvitationClaimed
+	}
+	// tokens verified locally carry no email unless the pool adds the claim
+	email := authedUser.Email
+	if email == "" {
+		if email, err = o.Directory.UserEmail(ctx, authedUser.Id); err != nil {
+			return nil, fmt.Errorf("org handler could not read caller email: %w", err)
+		}
+	}
+	if email == "" || !strings.EqualFold(invitation.Email, email) {
+		return nil, status.Error(codes.PermissionDenied, "invitation was issued to a different email")
+	}
+	// users who signed up on their own leave their pending organization
+	var pendingOrgId string
+	if model.IsPendingOrg(authedUser.OrgId) {
+		pendingOrgId = authedUser.OrgId
+	} else if authedUser.OrgId != "" && authedUser.OrgId != invitation.OrgId {
+		return nil, status.Error(codes.FailedPrecondition, "caller already belongs to another organization")
+	}
+
+	if err := o.requireConsents(ctx, authedUser.Id); err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "accepting invitation: %s - %s - %s", authedUser.Id, invitation.OrgId, invitation.InvitationId)
+	if _, err := dba.Repo.ClaimInvitation(ctx, invitation.InvitationId, authedUser.Id); err != nil {
+		return nil, fmt.Errorf("org handler could not claim invitation: %w", err)
+	}
+
+	repo := dba.Profiles.ForOrg(invitation.OrgId)
+	created, err := createProfile(ctx, repo, authedUser.Id, model.UpdateProfileRequest{
+		Email:       invitation.Email,
+		Name:        req.Name,
+		LegalName:   req.LegalName,
+		UserName:    req.UserName,
+		Roles:       []string{invitation.Role},
+		Address:     req.Address,
+		DateOfBirth: req.DateOfBirth,
+	})
+	if err != nil {
+		releaseInvitation(ctx, invitation.InvitationId)
+		return nil, err
+	}
+
+	membership := model.Membership{
+		UserId:   authedUser.Id,
+		OrgId:    invitation.OrgId,
+		JoinedAt: time.Now().UTC(),
+	}
+	if pendingOrgId != "" {
+		_, err = dba.Repo.MoveMembership(ctx, pendingOrgId, membership)
+	} else {
+		_, err = dba.Repo.AddMembership(ctx, membership)
+	}
+	if err != nil {
+		undoAcceptance(ctx, repo, invitation, authedUser.Id, pendingOrgId, false)
+		return nil, fmt.Errorf("org handler could not record membership: %w", err)
+	}
+
+	if o.Directory != nil {
+		if err := o.Directory.SetUserOrg(ctx, authedUser.Id, invitation.OrgId); err != nil {
+			undoAcceptance(ctx, repo, invitation, authedUser.Id, pendingOrgId, true)
+			return nil, fmt.Errorf("org handler could not link user to organization: %w", err)
+		}
+	}
+
+	if pendingOrgId != "" {
+		if err := dba.Profiles.ForOrg(pendingOrgId).DeleteProfile(ctx, authedUser.Id); err != nil && !errors.Is(err, dba.ErrProfileNotFound) {
+			log.WarnfCtx(ctx, "could not remove pending profile of %s: %v", authedUser.Id, err)
+		}
+	}
+
+	if err := recordHistory(ctx, authedUser, invitation.OrgId, authedUser.Id, model.HistoryInvitationAccepted, map[string]string{
+		"invitationId": invitation.InvitationId,
+		"invitedBy":    invitation.InvitedBy,
+		"role":         invitation.Role,
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not record invitation acceptance: %v", err)
+	}
+
+	return &profile.AcceptInvitationResponse{Profile: created}, nil
+}
+
+func (o *ProfileServer) ListMembers(ctx context.Context, req *profile.ListMembersRequest) (*profile.ListMembersResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := requireOrgMember(ctx, authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "listing members: %s", authedUser.OrgId)
+	members, err := repo.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not list members: %w", err)
+	}
+
+	return &profile.ListMembersResponse{Members: conversions.ConvertMembersToProto(members)}, nil
+}
+
+func (o *ProfileServer) RemoveMember(ctx context.Context, req *profile.RemoveMemberRequest) (*profile.RemoveMemberResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("org handler could not validate request: %w", err)
+	}
+
+	repo, err := requireOrgAdmin(ctx, authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// an admin removing themselves could leave the org without any admin
+	if req.UserId == authedUser.Id {
+		return nil, status.Error(codes.FailedPrecondition, "admins cannot remove themselves")
+	}
+
+	log.DebugfCtx(ctx, "removing member: %s - %s", authedUser.OrgId, req.UserId)
+	_, err = repo.ReadProfile(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not read member: %w", err)
+	}
+
+	// the profile goes last, so a removal that fails half way can be retried
+	if err := dba.Repo.RemoveMembership(ctx, req.UserId, authedUser.OrgId); err != nil && !errors.Is(err, dba.ErrMembershipNotFound) {
+		return nil, fmt.Errorf("org handler could not remove membership: %w", err)
+	}
+	if _, _, err := o.revokeAccess(ctx, authedUser, req.UserId, "removed from organization"); err != nil {
+		return nil, fmt.Errorf("org handler could not revoke member tokens: %w", err)
+	}
+	if o.Directory != nil {
+		if err := o.Directory.SetUserOrg(ctx, req.UserId, ""); err != nil {
+			return nil, fmt.Errorf("org handler could not unlink member from organization: %w", err)
+		}
+	}
+
+	err = repo.DeleteProfile(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("org handler could not remove member: %w", err)
+	}
+
+	if err := recordHistory(ctx, authedUser, authedUser.OrgId, req.UserId, model.HistoryMemberRemoved, nil); err != nil {
+		log.WarnfCtx(ctx, "could not record member removal: %v", err)
+	}
+
+	return &profile.RemoveMemberResponse{}, nil
+}
+
+// requireOrgAdmin returns the caller's org scoped repository when the caller's
+// identity provider groups or their profile in that org grant the admin role.
+// Nobody administers a pending organization.
+func requireOrgAdmin(ctx context.Context, user model.User) (dba.Repository, error) {
+	repo, err := orgRepo(user)
+	if err != nil {
+		return nil, err
+	}
+	if model.IsPendingOrg(user.OrgId) {
+		return nil, status.Error(codes.PermissionDenied, "caller is not an organization admin")
+	}
+
+	if model.HasRole(user.Roles, model.RoleAdmin) {
+		return repo, nil
+	}
+
+	caller, err := repo.ReadProfile(ctx, user.Id)
+	if errors.Is(err, dba.ErrProfileNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "caller is not an organization admin")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read caller profile: %w", err)
+	}
+	if !model.HasRole(caller.Roles, model.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, "caller is not an organization admin")
+	}
+
+	return repo, nil
+}
+
+// releaseInvitation hands a claimed invitation back so the invitee can retry
+func releaseInvitation(ctx context.Context, invitationId string) {
+	if err := dba.Repo.ReleaseInvitation(ctx, invitationId); err != nil {
+		log.WarnfCtx(ctx, "could not release invitation %s: %v", invitationId, err)
+	}
+}
+
+// undoAcceptance removes the profile, and the membership when it was already
+// recorded, of an acceptance that failed part way, then releases the
+// invitation. A user who came from a pending organization is moved back.
+func undoAcceptance(ctx context.Context, repo dba.Repository, invitation model.Invitation, userId, pendingOrgId string, member bool) {
+	switch {
+	case member && pendingOrgId != "":
+		if _, err := dba.Repo.MoveMembership(ctx, invitation.OrgId, model.Membership{
+			UserId:   userId,
+			OrgId:    pendingOrgId,
+			JoinedAt: time.Now().UTC(),
+		}); err != nil {
+			log.WarnfCtx(ctx, "could not restore pending membership of %s: %v", userId, err)
+		}
+	case member:
+		if err := dba.Repo.RemoveMembership(ctx, userId, invitation.OrgId); err != nil {
+			log.WarnfCtx(ctx, "could not remove membership of %s: %v", userId, err)
+		}
+	}
+	if err := repo.DeleteProfile(ctx, userId); err != nil {
+		log.WarnfCtx(ctx, "could not remove profile of %s: %v", userId, err)
+	}
+	releaseInvitation(ctx, invitation.InvitationId)
+}
+
+// requireOrgMember returns the caller's org scoped repository after checking
+// their membership record, which a removal ends at once while the middleware
+// may still remember the organization for a little while
+func requireOrgMember(ctx context.Context, user model.User) (dba.Repository, error) {
+	repo, err := orgRepo(user)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := dba.Repo.GetMembership(ctx, user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("could not read caller membership: %w", err)
+	}
+	if membership.OrgId != user.OrgId {
+		return nil, status.Error(codes.PermissionDenied, "caller is not a member of the organization")
+	}
+
+	return repo, nil
+}
+
+// generateInvitationToken returns a token of the form <invitationId>.<secret>
+// and the hash that is stored in place of it
+func generateInvitationToken(invitationId string) (token, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	token = invitationId + "." + hex.EncodeToString(secret)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
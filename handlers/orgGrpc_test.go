@@ -0,0 +1,579 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	orgAdminId   = "7D3E0C57-6A4B-4E43-9B8F-2B6C9E0A1F10"
+	orgInviteeId = "0F6A2C1B-3D4E-4F50-8A6B-7C8D9E0F1A2B"
+)
+
+// OrgDynamoMock keeps profiles and invitations in memory, enforcing the
+// conditions the repository relies on
+type OrgDynamoMock struct {
+	dynamodbiface.DynamoDBAPI
+	profiles    map[string]map[string]types.AttributeValue
+	invitations map[string]map[string]types.AttributeValue
+	memberships map[string]map[string]types.AttributeValue
+	apiKeys     map[string]map[string]types.AttributeValue
+	history     []map[string]types.AttributeValue
+	revocations []map[string]types.AttributeValue
+}
+
+func newOrgDynamoMock() *OrgDynamoMock {
+	return &OrgDynamoMock{
+		profiles:    map[string]map[string]types.AttributeValue{},
+		invitations: map[string]map[string]types.AttributeValue{},
+		memberships: map[string]map[string]types.AttributeValue{},
+		apiKeys:     map[string]map[string]types.AttributeValue{},
+	}
+}
+
+func attrS(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
+
+func profileKey(key map[string]types.AttributeValue) string {
+	return attrS(key, "OrgId") + "/" + attrS(key, "UserId")
+}
+
+func (m *OrgDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	switch *params.TableName {
+	case "Invitation":
+		return &dynamodb.GetItemOutput{Item: m.invitations[attrS(params.Key, "InvitationId")]}, nil
+	case "Membership":
+		return &dynamodb.GetItemOutput{Item: m.memberships[attrS(params.Key, "UserId")]}, nil
+	}
+	return &dynamodb.GetItemOutput{Item: m.profiles[profileKey(params.Key)]}, nil
+}
+
+func (m *OrgDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	switch *params.TableName {
+	case "Invitation":
+		m.invitations[attrS(params.Item, "InvitationId")] = params.Item
+		return &dynamodb.PutItemOutput{}, nil
+	case "History":
+		m.history = append(m.history, params.Item)
+		return &dynamodb.PutItemOutput{}, nil
+	case "Revocation":
+		m.revocations = append(m.revocations, params.Item)
+		return &dynamodb.PutItemOutput{}, nil
+	case "Membership":
+		existing, ok := m.memberships[attrS(params.Item, "UserId")]
+		if from, moving := params.ExpressionAttributeValues[":fromOrgId"]; moving {
+			if !ok || attrS(existing, "OrgId") != from.(*types.AttributeValueMemberS).Value {
+				return nil, &types.ConditionalCheckFailedException{}
+			}
+		} else if ok && attrS(existing, "OrgId") != attrS(params.Item, "OrgId") {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+		m.memberships[attrS(params.Item, "UserId")] = params.Item
+		return &dynamodb.PutItemOutput{}, nil
+	}
+	key := profileKey(params.Item)
+	if _, ok := m.profiles[key]; ok && params.ConditionExpression != nil {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.profiles[key] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *OrgDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	if *params.TableName == "ApiKey" {
+		item := m.apiKeys[attrS(params.Key, "KeyId")]
+		item["RevokedAt"] = params.ExpressionAttributeValues[":revokedAt"]
+		return &dynamodb.UpdateItemOutput{Attributes: item}, nil
+	}
+	item := m.invitations[attrS(params.Key, "InvitationId")]
+	if params.ConditionExpression == nil {
+		delete(item, "AcceptedAt")
+		delete(item, "AcceptedBy")
+		return &dynamodb.UpdateItemOutput{}, nil
+	}
+	if _, claimed := item["AcceptedAt"]; item == nil || claimed {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	item["AcceptedAt"] = params.ExpressionAttributeValues[":now"]
+	item["AcceptedBy"] = params.ExpressionAttributeValues[":userId"]
+	return &dynamodb.UpdateItemOutput{Attributes: item}, nil
+}
+
+func (m *OrgDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	out := &dynamodb.QueryOutput{}
+	if *params.TableName == "ApiKey" {
+		for _, item := range m.apiKeys {
+			if attrS(item, "UserId") == attrS(params.ExpressionAttributeValues, ":userId") {
+				out.Items = append(out.Items, item)
+			}
+		}
+		return out, nil
+	}
+	if *params.TableName != "Profile" {
+		return out, nil
+	}
+	orgId := attrS(params.ExpressionAttributeValues, ":orgId")
+	for _, item := range m.profiles {
+		if attrS(item, "OrgId") == orgId {
+			out.Items = append(out.Items, item)
+		}
+	}
+	return out, nil
+}
+
+func (m *OrgDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	if *params.TableName == "Membership" {
+		userId := attrS(params.Key, "UserId")
+		existing, ok := m.memberships[userId]
+		if !ok || attrS(existing, "OrgId") != attrS(params.ExpressionAttributeValues, ":orgId") {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+		delete(m.memberships, userId)
+		return &dynamodb.DeleteItemOutput{}, nil
+	}
+	key := profileKey(params.Key)
+	if _, ok := m.profiles[key]; !ok {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	delete(m.profiles, key)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+// TransactWriteItems only supports the profile merge, every item must target
+// an existing, unmerged profile
+func (m *OrgDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	for _, item := range params.TransactItems {
+		var key map[string]types.AttributeValue
+		if item.Put != nil {
+			key = item.Put.Item
+		} else {
+			key = item.Update.Key
+		}
+		existing, ok := m.profiles[profileKey(key)]
+		if _, merged := existing["MergedInto"]; !ok || merged {
+			return nil, &types.TransactionCanceledException{}
+		}
+	}
+	for _, item := range params.TransactItems {
+		if item.Put != nil {
+			m.profiles[profileKey(item.Put.Item)] = item.Put.Item
+			continue
+		}
+		existing := m.profiles[profileKey(item.Update.Key)]
+		existing["MergedInto"] = item.Update.ExpressionAttributeValues[":survivorId"]
+		existing["MergedAt"] = item.Update.ExpressionAttributeValues[":mergedAt"]
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+type fakeDirectory struct {
+	orgs      map[string]string
+	emails    map[string]string
+	signedOut []string
+	err       error
+}
+
+func (d *fakeDirectory) SetUserOrg(ctx context.Context, userId, orgId string) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.orgs[userId] = orgId
+	return nil
+}
+
+func (d *fakeDirectory) UserEmail(ctx context.Context, userId string) (string, error) {
+	return d.emails[userId], nil
+}
+
+func (d *fakeDirectory) SignOutUser(ctx context.Context, userId string) error {
+	d.signedOut = append(d.signedOut, userId)
+	return nil
+}
+
+func setupOrgTest(t *testing.T) *OrgDynamoMock {
+	dynMock := newOrgDynamoMock()
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{
+			ProfileTableName:    "Profile",
+			InvitationTableName: "Invitation",
+			ConsentTableName:    "Consent",
+			HistoryTableName:    "History",
+			SessionTableName:    "Session",
+			RevocationTableName: "Revocation",
+			MembershipTableName: "Membership",
+			ApiKeyTableName:     "ApiKey",
+		},
+		Svc: dynMock,
+	})
+
+	admin, err := attributevalue.MarshalMap(model.UpdateProfileRequest{
+		OrgId:  "org-1",
+		UserId: orgAdminId,
+		Email:  "admin@example.com",
+		Roles:  []string{model.RoleAdmin},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynMock.profiles["org-1/"+orgAdminId] = admin
+	addMembership(dynMock, orgAdminId, "org-1")
+	return dynMock
+}
+
+func addMembership(dynMock *OrgDynamoMock, userId, orgId string) {
+	dynMock.memberships[userId] = map[string]types.AttributeValue{
+		"UserId": &types.AttributeValueMemberS{Value: userId},
+		"OrgId":  &types.AttributeValueMemberS{Value: orgId},
+	}
+}
+
+func orgCtx(user model.User) context.Context {
+	ctx := ctxlogrus.ToContext(context.TODO(), logrus.NewEntry(logrus.New()))
+	return context.WithValue(ctx, model.UserCtxKey, user)
+}
+
+func acceptRequest(token string) *profile.AcceptInvitationRequest {
+	return &profile.AcceptInvitationRequest{
+		Token:       token,
+		Name:        "Bob Ross",
+		LegalName:   "Robert Ross",
+		UserName:    "bross",
+		Address:     "Some Mountain, Canada",
+		DateOfBirth: "10/29/1942",
+	}
+}
+
+func TestInvitationFlow(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	dir := &fakeDirectory{orgs: map[string]string{}}
+	ps := ProfileServer{Directory: dir}
+
+	adminCtx := orgCtx(model.User{Id: orgAdminId, OrgId: "org-1", Email: "admin@example.com"})
+	inviteeCtx := orgCtx(model.User{Id: orgInviteeId, Email: "Bob@Example.com"})
+
+	invited, err := ps.InviteMember(adminCtx, &profile.InviteMemberRequest{
+		Email: "bob@example.com",
+		Role:  model.RoleMember,
+	})
+	if err != nil {
+		t.Fatalf("unexpected invite error: %v", err)
+	}
+	if invited.Token == "" || invited.Invitation.OrgId != "org-1" {
+		t.Fatalf("unexpected invitation: %v", invited)
+	}
+
+	wrongUser := orgCtx(model.User{Id: orgInviteeId, Email: "mallory@example.com"})
+	if _, err = ps.AcceptInvitation(wrongUser, acceptRequest(invited.Token)); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for another email, got %v", err)
+	}
+
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Invitation.InvitationId+".forged")); errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound for a forged token, got %v", err)
+	}
+
+	accepted, err := ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token))
+	if err != nil {
+		t.Fatalf("unexpected accept error: %v", err)
+	}
+	if accepted.Profile.UserId != orgInviteeId || accepted.Profile.OrgId != "org-1" || !model.HasRole(accepted.Profile.Roles, model.RoleMember) {
+		t.Fatalf("unexpected profile: %v", accepted.Profile)
+	}
+	if dir.orgs[orgInviteeId] != "org-1" {
+		t.Fatal("expected the invitee to be linked to the org")
+	}
+	if attrS(dynMock.memberships[orgInviteeId], "OrgId") != "org-1" {
+		t.Fatal("expected the invitee to become a member of the org")
+	}
+
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected the token to be single use, got %v", err)
+	}
+
+	members, err := ps.ListMembers(adminCtx, &profile.ListMembersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members.Members))
+	}
+
+	memberCtx := orgCtx(model.User{Id: orgInviteeId, OrgId: "org-1"})
+	if _, err = ps.InviteMember(memberCtx, &profile.InviteMemberRequest{Email: "eve@example.com", Role: model.RoleAdmin}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a non admin, got %v", err)
+	}
+	if _, err = ps.RemoveMember(memberCtx, &profile.RemoveMemberRequest{UserId: orgAdminId}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a non admin, got %v", err)
+	}
+
+	if _, err = ps.RemoveMember(adminCtx, &profile.RemoveMemberRequest{UserId: orgAdminId}); errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition removing self, got %v", err)
+	}
+	dynMock.apiKeys["key-1"] = map[string]types.AttributeValue{
+		"KeyId":  &types.AttributeValueMemberS{Value: "key-1"},
+		"UserId": &types.AttributeValueMemberS{Value: orgInviteeId},
+	}
+	if _, err = ps.RemoveMember(adminCtx, &profile.RemoveMemberRequest{UserId: orgInviteeId}); err != nil {
+		t.Fatalf("unexpected remove error: %v", err)
+	}
+	if _, ok := dynMock.memberships[orgInviteeId]; ok {
+		t.Fatal("expected the membership to end")
+	}
+	if org, ok := dir.orgs[orgInviteeId]; !ok || org != "" {
+		t.Fatalf("expected the org attribute to be cleared, got %q", org)
+	}
+	if len(dir.signedOut) != 1 || dir.signedOut[0] != orgInviteeId || len(dynMock.revocations) != 1 {
+		t.Fatalf("expected the member's tokens to be revoked, got %v %v", dir.signedOut, dynMock.revocations)
+	}
+	if _, revoked := dynMock.apiKeys["key-1"]["RevokedAt"]; !revoked {
+		t.Fatal("expected the member's api keys to be revoked")
+	}
+	// the middleware may still remember the removed member's organization
+	if _, err = ps.ListMembers(memberCtx, &profile.ListMembersRequest{}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied listing members after removal, got %v", err)
+	}
+	if _, err = ps.RemoveMember(adminCtx, &profile.RemoveMemberRequest{UserId: orgInviteeId}); errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound removing twice, got %v", err)
+	}
+
+	if len(dynMock.history) != 2 ||
+		attrS(dynMock.history[0], "Action") != model.HistoryInvitationAccepted ||
+		attrS(dynMock.history[1], "Action") != model.HistoryMemberRemoved ||
+		attrS(dynMock.history[1], "ActorId") != orgAdminId {
+		t.Fatalf("unexpected history: %v", dynMock.history)
+	}
+}
+
+func TestAcceptInvitationWithoutEmailClaim(t *testing.T) {
+	setupOrgTest(t)
+	dir := &fakeDirectory{orgs: map[string]string{}, emails: map[string]string{}}
+	ps := ProfileServer{Directory: dir}
+
+	adminCtx := orgCtx(model.User{Id: orgAdminId, OrgId: "org-1", Email: "admin@example.com"})
+	invited, err := ps.InviteMember(adminCtx, &profile.InviteMemberRequest{Email: "bob@example.com", Role: model.RoleMember})
+	if err != nil {
+		t.Fatalf("unexpected invite error: %v", err)
+	}
+
+	// a locally verified access token carries no email
+	inviteeCtx := orgCtx(model.User{Id: orgInviteeId})
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied without any email, got %v", err)
+	}
+
+	dir.emails[orgInviteeId] = "Bob@Example.com"
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); err != nil {
+		t.Fatalf("expected the email from the directory to match, got %v", err)
+	}
+}
+
+func TestAcceptInvitationUndoesFailedLink(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	dir := &fakeDirectory{orgs: map[string]string{}, err: errors.New("cognito unavailable")}
+	ps := ProfileServer{Directory: dir}
+
+	invited, err := ps.InviteMember(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.InviteMemberRequest{
+		Email: "bob@example.com",
+		Role:  model.RoleMember,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inviteeCtx := orgCtx(model.User{Id: orgInviteeId, Email: "bob@example.com"})
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); err == nil {
+		t.Fatal("expected the failed link to fail the acceptance")
+	}
+	if _, ok := dynMock.profiles["org-1/"+orgInviteeId]; ok {
+		t.Fatal("expected the profile to be removed")
+	}
+	if _, ok := dynMock.memberships[orgInviteeId]; ok {
+		t.Fatal("expected the membership to be removed")
+	}
+
+	// the invitation was handed back, so the invitee can retry
+	dir.err = nil
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if dir.orgs[orgInviteeId] != "org-1" {
+		t.Fatal("expected the invitee to be linked to the org")
+	}
+}
+
+func TestAcceptInvitationLeavesPendingOrg(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	dir := &fakeDirectory{orgs: map[string]string{}, err: errors.New("cognito unavailable")}
+	ps := ProfileServer{Directory: dir}
+
+	pending := model.PendingOrgId(orgInviteeId)
+	addOrgMember(t, dynMock, pending, orgInviteeId)
+	addMembership(dynMock, orgInviteeId, pending)
+
+	invited, err := ps.InviteMember(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.InviteMemberRequest{
+		Email: "bob@example.com",
+		Role:  model.RoleMember,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ps.InviteMember(orgCtx(model.User{Id: orgInviteeId, OrgId: pending, Roles: []string{model.RoleAdmin}}), &profile.InviteMemberRequest{
+		Email: "eve@example.com",
+		Role:  model.RoleMember,
+	}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected a pending organization to have no admin, got %v", err)
+	}
+
+	inviteeCtx := orgCtx(model.User{Id: orgInviteeId, OrgId: pending, Email: "bob@example.com"})
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); err == nil {
+		t.Fatal("expected the failed link to fail the acceptance")
+	}
+	if attrS(dynMock.memberships[orgInviteeId], "OrgId") != pending {
+		t.Fatalf("expected the pending membership to be restored, got %v", dynMock.memberships[orgInviteeId])
+	}
+
+	dir.err = nil
+	if _, err = ps.AcceptInvitation(inviteeCtx, acceptRequest(invited.Token)); err != nil {
+		t.Fatalf("expected a pending user to accept, got %v", err)
+	}
+	if attrS(dynMock.memberships[orgInviteeId], "OrgId") != "org-1" {
+		t.Fatalf("expected the membership to move to org-1, got %v", dynMock.memberships[orgInviteeId])
+	}
+	if _, ok := dynMock.profiles["org-1/"+orgInviteeId]; !ok {
+		t.Fatal("expected a profile in org-1")
+	}
+	if _, ok := dynMock.profiles[pending+"/"+orgInviteeId]; ok {
+		t.Fatal("expected the pending profile to be removed")
+	}
+}
+
+func TestRequireOrgAdminFromGroups(t *testing.T) {
+	setupOrgTest(t)
+
+	// no profile in the org, the admin role comes from the caller's groups
+	if _, err := requireOrgAdmin(context.Background(), model.User{Id: "group-admin", OrgId: "org-1", Roles: []string{model.RoleAdmin}}); err != nil {
+		t.Fatalf("expected group admin to be allowed, got %v", err)
+	}
+	if _, err := requireOrgAdmin(context.Background(), model.User{Id: "group-admin", OrgId: "org-1", Groups: []string{"org-admins"}}); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected unmapped groups to be denied, got %v", err)
+	}
+}
+
+func TestAcceptExpiredInvitation(t *testing.T) {
+	setupOrgTest(t)
+	ps := ProfileServer{}
+
+	token, hash, err := generateInvitationToken("inv-expired")
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if _, err = dba.Repo.CreateInvitation(context.TODO(), model.Invitation{
+		InvitationId: "inv-expired",
+		OrgId:        "org-1",
+		Email:        "bob@example.com",
+		Role:         model.RoleMember,
+		TokenHash:    hash,
+		CreatedAt:    past.Add(-time.Hour),
+		ExpiresAt:    past,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := orgCtx(model.User{Id: orgInviteeId, Email: "bob@example.com"})
+	if _, err = ps.AcceptInvitation(ctx, acceptRequest(token)); errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestAcceptInvitationFromAnotherOrg(t *testing.T) {
+	setupOrgTest(t)
+	ps := ProfileServer{}
+
+	invited, err := ps.InviteMember(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.InviteMemberRequest{
+		Email: "bob@example.com",
+		Role:  model.RoleMember,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := orgCtx(model.User{Id: orgInviteeId, OrgId: "org-2", Email: "bob@example.com"})
+	if _, err = ps.AcceptInvitation(ctx, acceptRequest(invited.Token)); errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestCreateProfileHandler(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	ps := ProfileServer{}
+	ctx := orgCtx(model.User{Id: orgInviteeId, OrgId: "org-1"})
+
+	req := &profile.CreateProfileRequest{
+		Id:          orgInviteeId,
+		Email:       "bob@example.com",
+		Name:        "Bob Ross",
+		LegalName:   "Robert Ross",
+		UserName:    "bross",
+		Address:     "Some Mountain, Canada",
+		DateOfBirth: "10/29/1942",
+	}
+
+	// an org claim without a membership is not enough
+	if _, err := ps.CreateProfile(ctx, req); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied without a membership, got %v", err)
+	}
+	addMembership(dynMock, orgInviteeId, "org-1")
+
+	resp, err := ps.CreateProfile(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if resp.UserId != orgInviteeId || resp.OrgId != "org-1" || len(resp.Roles) != 0 {
+		t.Fatalf("unexpected profile: %v", resp)
+	}
+
+	if _, err = ps.CreateProfile(ctx, req); errs.ToStatus(err).Code() != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+
+	req.Id = orgAdminId
+	if _, err = ps.CreateProfile(ctx, req); errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied creating another user's profile, got %v", err)
+	}
+}
@@ -18,28 +18,72 @@ package handlers
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
 	"github.com/coinbase-samples/ib-usermgr-go/conversions"
 	"github.com/coinbase-samples/ib-usermgr-go/dba"
 	"github.com/coinbase-samples/ib-usermgr-go/log"
 	"github.com/coinbase-samples/ib-usermgr-go/model"
 	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ProfileServer struct {
 	profile.UnimplementedProfileServiceServer
+	// RequiredConsents maps document type to the version that must be
+	// accepted before any mutating profile call is allowed
+	RequiredConsents map[string]string
+	// Directory links users who accept an invitation to their new
+	// organization, unlinks removed members and signs out users whose tokens
+	// are revoked, left nil all of it has to be done by hand
+	Directory Directory
+	// Impersonators holds the user ids of support staff allowed to act as
+	// customers through impersonation grants
+	Impersonators map[string]bool
+	// Attributes copies email and name changes to the identity provider,
+	// left nil they only change in the profile
+	Attributes AttributeSyncer
+	// StepUp asks for a recent sign in before sensitive profile fields
+	// change, left nil any sign in will do
+	StepUp *auth.StepUpPolicy
+	// RevokedTokenTtl is how long a single revoked token is rejected for,
+	// defaulting to the longest lifetime of a Cognito access token
+	RevokedTokenTtl time.Duration
+}
+
+// Directory records a user's organization with the identity provider, reads
+// their email and ends their sign ins there
+type Directory interface {
+	SetUserOrg(ctx context.Context, userId, orgId string) error
+	UserEmail(ctx context.Context, userId string) (string, error)
+	SignOutUser(ctx context.Context, userId string) error
+}
+
+// AttributeSyncer writes a user's attributes to the identity provider
+type AttributeSyncer interface {
+	SyncAttributes(ctx context.Context, userId string, attributes map[string]string) error
 }
 
 func (o *ProfileServer) ReadProfile(ctx context.Context, req *profile.ReadProfileRequest) (*profile.ReadProfileResponse, error) {
-	authedUser := ctx.Value(model.UserCtxKey).(model.User)
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if err := req.ValidateAll(); err != nil {
 		return nil, fmt.Errorf("profile handler could not validate request: %w", err)
 	}
 
+	repo, err := orgRepo(authedUser)
+	if err != nil {
+		return nil, err
+	}
+
 	log.DebugfCtx(ctx, "fetching user - %s - %s", authedUser.Id, req.Id)
-	body, err := dba.Repo.ReadProfile(authedUser.Id)
+	body, err := repo.ReadProfile(ctx, authedUser.Id)
 
 	if err != nil {
 		return nil, fmt.Errorf("profile handler could not read profile: %w", err)
@@ -47,31 +91,155 @@ func (o *ProfileServer) ReadProfile(ctx context.Context, req *profile.ReadProfil
 
 	response := conversions.ConvertReadProfileToProto(body)
 
-	log.DebugfCtx(ctx, "returning read profile response - %v", &response)
+	log.DebugfCtx(ctx, "returning read profile response - %v", log.Redacted(&response))
 	return &response, nil
 }
 
 func (o *ProfileServer) UpdateProfile(ctx context.Context, req *profile.UpdateProfileRequest) (*profile.UpdateProfileResponse, error) {
-	authedUser := ctx.Value(model.UserCtxKey).(model.User)
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if err := req.ValidateAll(); err != nil {
 		return nil, fmt.Errorf("profile handler could not validate request: %w", err)
 	}
 
+	repo, err := orgRepo(authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.requireConsents(ctx, authedUser.Id); err != nil {
+		return nil, err
+	}
+
 	updateBody := conversions.ConvertUpdateProfileToModel(req)
 
+	previous, err := repo.ReadProfile(ctx, authedUser.Id)
+	if err != nil {
+		return nil, fmt.Errorf("profile handler could not read profile: %w", err)
+	}
+
+	if o.StepUp != nil {
+		if err := o.StepUp.Check(authedUser, changedProfileFields(previous, updateBody)); err != nil {
+			return nil, err
+		}
+	}
+
+	// the step up check looked at this version, a concurrent change fails
+	updateBody.UpdatedAt = previous.UpdatedAt
+
 	log.DebugfCtx(ctx, "updating user: %s - %s", authedUser.Id, req.Id)
-	body, err := dba.Repo.UpdateProfile(authedUser.Id, updateBody)
+	body, err := repo.UpdateProfile(ctx, authedUser.Id, updateBody)
 
 	if err != nil {
 		return nil, fmt.Errorf("profile handler could not update profile: %w", err)
 	}
 
+	o.syncAttributes(ctx, previous, body)
+
+	if err := recordHistory(ctx, authedUser, authedUser.OrgId, authedUser.Id, model.HistoryProfileUpdated, nil); err != nil {
+		log.WarnfCtx(ctx, "could not record profile update: %v", err)
+	}
+
 	response := conversions.ConvertUpdateProfileToProto(body)
 
-	log.DebugfCtx(ctx, "returning update profile response - %v", &response)
+	log.DebugfCtx(ctx, "returning update profile response - %v", log.Redacted(&response))
 	return &response, nil
 }
 
+// changedProfileFields names the fields an update changes, using the field
+// names of the UpdateProfile request
+func changedProfileFields(previous model.ProfileResponse, update model.UpdateProfileRequest) []string {
+	var changed []string
+	for _, f := range []struct {
+		name            string
+		previous, value string
+	}{
+		{"email", previous.Email, update.Email},
+		{"name", previous.Name, update.Name},
+		{"legal_name", previous.LegalName, update.LegalName},
+		{"user_name", previous.UserName, update.UserName},
+		{"address", previous.Address, update.Address},
+		{"date_of_birth", previous.DateOfBirth, update.DateOfBirth},
+	} {
+		if f.previous != f.value {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed
+}
+
+// syncAttributes copies email and name to the identity provider when either
+// changed. A failure does not fail the request, the synchronizer keeps the
+// update for a later retry or a replay.
+func (o *ProfileServer) syncAttributes(ctx context.Context, previous, current model.ProfileResponse) {
+	if o.Attributes == nil || (previous.Email == current.Email && previous.Name == current.Name) {
+		return
+	}
+	if err := o.Attributes.SyncAttributes(ctx, current.UserId, map[string]string{
+		auth.EmailAttribute: current.Email,
+		auth.NameAttribute:  current.Name,
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not sync attributes of %s: %v", current.UserId, err)
+	}
+}
+
+// orgRepo confines the repository to the caller's organization, refusing
+// callers that do not belong to one
+func orgRepo(user model.User) (dba.Repository, error) {
+	if user.OrgId == "" {
+		return nil, status.Error(codes.PermissionDenied, "caller does not belong to an organization")
+	}
+	return dba.Profiles.ForOrg(user.OrgId), nil
+}
+
 func (o *ProfileServer) CreateProfile(ctx context.Context, req *profile.CreateProfileRequest) (*profile.CreateProfileResponse, error) {
-	return nil, errors.New("method not implemented")
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("profile handler could not validate request: %w", err)
+	}
+
+	// users can only create their own profile, others join through invitations
+	if req.Id != authedUser.Id {
+		return nil, status.Error(codes.PermissionDenied, "profiles can only be created for the caller")
+	}
+
+	repo, err := requireOrgMember(ctx, authedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.requireConsents(ctx, authedUser.Id); err != nil {
+		return nil, err
+	}
+
+	createBody := conversions.ConvertCreateProfileToModel(req)
+
+	log.DebugfCtx(ctx, "creating user: %s", authedUser.Id)
+	response, err := createProfile(ctx, repo, authedUser.Id, createBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordHistory(ctx, authedUser, authedUser.OrgId, authedUser.Id, model.HistoryProfileCreated, nil); err != nil {
+		log.WarnfCtx(ctx, "could not record profile creation: %v", err)
+	}
+
+	return response, nil
+}
+
+// createProfile is shared by CreateProfile and AcceptInvitation, repo decides
+// which organization the profile lands in
+func createProfile(ctx context.Context, repo dba.Repository, id string, createBody model.UpdateProfileRequest) (*profile.CreateProfileResponse, error) {
+	body, err := repo.CreateProfile(ctx, id, createBody)
+	if err != nil {
+		return nil, fmt.Errorf("profile handler could not create profile: %w", err)
+	}
+
+	response := conversions.ConvertCreateProfileToProto(body)
+	return &response, nil
 }
@@ -18,18 +18,23 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
 	"github.com/coinbase-samples/ib-usermgr-go/config"
 	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
 	"github.com/coinbase-samples/ib-usermgr-go/model"
 	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -37,6 +42,7 @@ var (
 	ReadProfileFound      = "20032259-738B-40A7-AAD7-306B69AF88D4"
 	UpdateProfileNotFound = "E6096F2D-C706-42B6-B0E5-D7DD644ED079"
 	UpdateProfile         = "AC032259-738B-40A7-AAD7-306B69AAB909"
+	ConsentFound          = "0B5B0E7C-5C2B-4D67-9E0F-52C8E5A8D1F3"
 )
 
 type DynamoMock struct {
@@ -44,6 +50,9 @@ type DynamoMock struct {
 }
 
 func (m *DynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if id, ok := params.Key["UserId"].(*types.AttributeValueMemberS); ok && id.Value == ReadProfileNotFound {
+		return &dynamodb.GetItemOutput{}, nil
+	}
 	return &dynamodb.GetItemOutput{
 		Item: map[string]types.AttributeValue{
 			"UserId": &types.AttributeValueMemberS{Value: ReadProfileFound},
@@ -61,12 +70,67 @@ func (m *DynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput,
 	}, nil
 }
 
+func (m *DynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	if *params.TableName == "Profile" {
+		return &dynamodb.UpdateItemOutput{
+			Attributes: map[string]types.AttributeValue{
+				"UserId": params.Key["UserId"],
+				"Name":   params.ExpressionAttributeValues[":Name"],
+			},
+		}, nil
+	}
+	return &dynamodb.UpdateItemOutput{
+		Attributes: map[string]types.AttributeValue{
+			"UserId":          &types.AttributeValueMemberS{Value: "123"},
+			"ConsentId":       &types.AttributeValueMemberS{Value: ConsentFound},
+			"DocumentType":    &types.AttributeValueMemberS{Value: "tos"},
+			"DocumentVersion": &types.AttributeValueMemberS{Value: "2023-01"},
+			"WithdrawnAt":     &types.AttributeValueMemberS{Value: "2023-02-01T00:00:00Z"},
+		},
+	}, nil
+}
+
+func (m *DynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	if *params.TableName == "Session" {
+		return &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"UserId":    &types.AttributeValueMemberS{Value: "123"},
+					"SessionId": &types.AttributeValueMemberS{Value: "current"},
+				},
+				{
+					"UserId":    &types.AttributeValueMemberS{Value: "123"},
+					"SessionId": &types.AttributeValueMemberS{Value: "other"},
+				},
+			},
+		}, nil
+	}
+	return &dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			{
+				"UserId":          &types.AttributeValueMemberS{Value: "123"},
+				"ConsentId":       &types.AttributeValueMemberS{Value: ConsentFound},
+				"DocumentType":    &types.AttributeValueMemberS{Value: "tos"},
+				"DocumentVersion": &types.AttributeValueMemberS{Value: "2023-01"},
+			},
+		},
+	}, nil
+}
+
+func (m *DynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *DynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
 func TestReadHandler(t *testing.T) {
 	l := logrus.New()
 	entry := logrus.NewEntry(l)
 	ctx := context.TODO()
 	ctx = ctxlogrus.ToContext(ctx, entry)
-	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123"})
+	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
 
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -93,12 +157,31 @@ func TestReadHandler(t *testing.T) {
 	}
 }
 
+func TestReadHandlerNotFound(t *testing.T) {
+	ctx := ctxlogrus.ToContext(context.TODO(), logrus.NewEntry(logrus.New()))
+	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: ReadProfileNotFound, OrgId: "org-1"})
+
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(DynamoMock),
+	})
+
+	ps := ProfileServer{}
+	_, err := ps.ReadProfile(ctx, &profile.ReadProfileRequest{
+		Id: ReadProfileNotFound,
+	})
+
+	if errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected not found for a missing profile, got %v", err)
+	}
+}
+
 func TestReadHandlerInvalidId(t *testing.T) {
 	l := logrus.New()
 	entry := logrus.NewEntry(l)
 	ctx := context.TODO()
 	ctx = ctxlogrus.ToContext(ctx, entry)
-	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123"})
+	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
 
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -132,7 +215,7 @@ func TestUpdateHandler(t *testing.T) {
 	entry := logrus.NewEntry(l)
 	ctx := context.TODO()
 	ctx = ctxlogrus.ToContext(ctx, entry)
-	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123"})
+	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
 
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -160,8 +243,9 @@ func TestUpdateHandler(t *testing.T) {
 		t.Fatal("unexpected update profile error")
 	}
 
-	if resp.UserId != UpdateProfile {
-		t.Fatalf("unexpected user id returned, got %s expected %s", resp.UserId, UpdateProfile)
+	// the caller's own profile is updated whatever id the request names
+	if resp.UserId != "123" {
+		t.Fatalf("unexpected user id returned, got %s expected 123", resp.UserId)
 	}
 
 	if resp.Name != "Bob Ross" {
@@ -169,12 +253,79 @@ func TestUpdateHandler(t *testing.T) {
 	}
 }
 
+type fakeAttributeSyncer struct {
+	synced map[string]map[string]string
+}
+
+func (f *fakeAttributeSyncer) SyncAttributes(ctx context.Context, userId string, attributes map[string]string) error {
+	f.synced[userId] = attributes
+	return nil
+}
+
+func TestUpdateHandlerSyncsAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
+
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(DynamoMock),
+	})
+
+	syncer := &fakeAttributeSyncer{synced: map[string]map[string]string{}}
+	ps := ProfileServer{Attributes: syncer}
+
+	if _, err := ps.UpdateProfile(ctx, &profile.UpdateProfileRequest{
+		Id:          UpdateProfile,
+		Name:        "Bob Ross",
+		Email:       "b.ross@coinbase.com",
+		LegalName:   "Bob Ross",
+		UserName:    "demo0",
+		Address:     "123 Happy Way",
+		DateOfBirth: "The best day",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stored name changes from Ted Robinson to Bob Ross
+	if got := syncer.synced["123"]["name"]; got != "Bob Ross" {
+		t.Fatalf("expected name to be synced, got %v", syncer.synced)
+	}
+}
+
+func TestUpdateHandlerRequiresStepUp(t *testing.T) {
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(DynamoMock),
+	})
+
+	ps := ProfileServer{StepUp: auth.NewStepUpPolicy([]string{"email"}, 15*time.Minute)}
+	req := &profile.UpdateProfileRequest{
+		Id:          UpdateProfile,
+		Name:        "Bob Ross",
+		Email:       "b.ross@coinbase.com",
+		LegalName:   "Bob Ross",
+		UserName:    "demo0",
+		Address:     "123 Happy Way",
+		DateOfBirth: "The best day",
+	}
+
+	stale := model.User{Id: "123", OrgId: "org-1", AuthTime: time.Now().Add(-time.Hour)}
+	_, err := ps.UpdateProfile(context.WithValue(context.Background(), model.UserCtxKey, stale), req)
+	if !errors.Is(err, auth.ErrStepUpRequired) {
+		t.Fatalf("expected step up for an email change after an old sign in, got %v", err)
+	}
+
+	fresh := model.User{Id: "123", OrgId: "org-1", AuthTime: time.Now().Add(-time.Minute)}
+	if _, err := ps.UpdateProfile(context.WithValue(context.Background(), model.UserCtxKey, fresh), req); err != nil {
+		t.Fatalf("unexpected error after a recent sign in: %v", err)
+	}
+}
+
 func TestUpdateHandlerEmailError(t *testing.T) {
 	l := logrus.New()
 	entry := logrus.NewEntry(l)
 	ctx := context.TODO()
 	ctx = ctxlogrus.ToContext(ctx, entry)
-	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123"})
+	ctx = context.WithValue(ctx, model.UserCtxKey, model.User{Id: "123", OrgId: "org-1"})
 
 	dynMock := new(DynamoMock)
 	app := config.AppConfig{
@@ -204,3 +355,33 @@ func TestUpdateHandlerEmailError(t *testing.T) {
 	}
 
 }
+
+func TestReadHandlerWithoutOrg(t *testing.T) {
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile"},
+		Svc: new(DynamoMock),
+	})
+
+	ps := ProfileServer{}
+	_, err := ps.ReadProfile(ctx, &profile.ReadProfileRequest{
+		Id: ReadProfileFound,
+	})
+
+	if errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected permission denied without an org, got %v", err)
+	}
+}
+
+func TestHandlersRequireCaller(t *testing.T) {
+	ps := ProfileServer{}
+	ctx := context.Background()
+
+	if _, err := ps.ReadProfile(ctx, &profile.ReadProfileRequest{Id: ReadProfileFound}); errs.ToStatus(err).Code() != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated without a user, got %v", err)
+	}
+	if _, err := ps.LookupProfile(ctx, &profile.LookupProfileRequest{OrgId: "org-1", UserId: ReadProfileFound}); errs.ToStatus(err).Code() != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated without a service, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultRevokedTokenTtl covers the longest access token lifetime Cognito
+// allows
+const defaultRevokedTokenTtl = 24 * time.Hour
+
+// RevokeUserTokens signs a member out everywhere, e.g. after a compromised
+// device report. Every token from a sign in up to now is rejected from the
+// next request on, however long it has left before it expires.
+func (o *ProfileServer) RevokeUserTokens(ctx context.Context, req *profile.RevokeUserTokensRequest) (*profile.RevokeUserTokensResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("revocation handler could not validate request: %w", err)
+	}
+
+	if err := requireMemberOfAdminOrg(ctx, authedUser, req.UserId); err != nil {
+		return nil, err
+	}
+
+	log.InfofCtx(ctx, "revoking tokens: %s - %s", authedUser.Id, req.UserId)
+	notBefore, revoked, err := o.revokeAccess(ctx, authedUser, req.UserId, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("revocation handler could not revoke tokens: %w", err)
+	}
+
+	if err := recordHistory(ctx, authedUser, authedUser.OrgId, req.UserId, model.HistoryTokensRevoked, map[string]string{
+		"notBefore": notBefore.Format(time.RFC3339),
+		"reason":    req.Reason,
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not record token revocation: %v", err)
+	}
+
+	return &profile.RevokeUserTokensResponse{
+		NotBefore: timestamppb.New(notBefore),
+		Revoked:   conversions.ConvertSessionsToProto(revoked, ""),
+	}, nil
+}
+
+// revokeAccess rejects every token of userId from a sign in up to now, revokes
+// their sessions and api keys and signs them out at the identity provider. It
+// returns the watermark and the sessions it revoked.
+func (o *ProfileServer) revokeAccess(ctx context.Context, actor model.User, userId, reason string) (time.Time, []model.Session, error) {
+	// token times have second precision, so the watermark is truncated to
+	// also cut off tokens issued earlier in the same second
+	now := time.Now().UTC()
+	notBefore := now.Truncate(time.Second)
+
+	if _, err := dba.Repo.RecordRevocation(ctx, model.Revocation{
+		UserId:       userId,
+		RevocationId: model.RevocationWatermark,
+		NotBefore:    &notBefore,
+		Reason:       reason,
+		RevokedBy:    actor.Id,
+		RevokedAt:    now,
+	}); err != nil {
+		return notBefore, nil, fmt.Errorf("could not record revocation: %w", err)
+	}
+
+	revoked, err := dba.Repo.RevokeOtherSessions(ctx, userId, "")
+	if err != nil {
+		return notBefore, nil, fmt.Errorf("could not revoke sessions: %w", err)
+	}
+
+	// the watermark already rejects keys created before it, revoking them
+	// as well shows in the keys the member lists
+	if err := revokeApiKeys(ctx, userId); err != nil {
+		return notBefore, nil, fmt.Errorf("could not revoke api keys: %w", err)
+	}
+
+	// the watermark already rejects tokens refreshed from earlier sign ins,
+	// signing out at the identity provider only stops them being minted
+	if o.Directory != nil {
+		if err := o.Directory.SignOutUser(ctx, userId); err != nil {
+			log.WarnfCtx(ctx, "could not sign out %s at the identity provider: %v", userId, err)
+		}
+	}
+
+	return notBefore, revoked, nil
+}
+
+// RevokeToken rejects a single access token of a member by its jti
+func (o *ProfileServer) RevokeToken(ctx context.Context, req *profile.RevokeTokenRequest) (*profile.RevokeTokenResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("revocation handler could not validate request: %w", err)
+	}
+
+	if err := requireMemberOfAdminOrg(ctx, authedUser, req.UserId); err != nil {
+		return nil, err
+	}
+
+	ttl := o.RevokedTokenTtl
+	if ttl <= 0 {
+		ttl = defaultRevokedTokenTtl
+	}
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+
+	log.InfofCtx(ctx, "revoking token: %s - %s - %s", authedUser.Id, req.UserId, req.TokenId)
+	if _, err := dba.Repo.RecordRevocation(ctx, model.Revocation{
+		UserId:       req.UserId,
+		RevocationId: model.TokenRevocationId(req.TokenId),
+		TokenId:      req.TokenId,
+		Reason:       req.Reason,
+		RevokedBy:    authedUser.Id,
+		RevokedAt:    now,
+		Ttl:          expiresAt.Unix(),
+	}); err != nil {
+		return nil, fmt.Errorf("revocation handler could not record revocation: %w", err)
+	}
+
+	if err := recordHistory(ctx, authedUser, authedUser.OrgId, req.UserId, model.HistoryTokenRevoked, map[string]string{
+		"tokenId": req.TokenId,
+		"reason":  req.Reason,
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not record token revocation: %v", err)
+	}
+
+	return &profile.RevokeTokenResponse{ExpiresAt: timestamppb.New(expiresAt)}, nil
+}
+
+// requireMemberOfAdminOrg checks the caller administers the organization
+// userId belongs to
+func requireMemberOfAdminOrg(ctx context.Context, user model.User, userId string) error {
+	repo, err := requireOrgAdmin(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.ReadProfile(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("could not read member profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc/codes"
+)
+
+const revocationReason = "reported a stolen laptop"
+
+func addOrgMember(t *testing.T, dynMock *OrgDynamoMock, orgId, userId string) {
+	t.Helper()
+	member, err := attributevalue.MarshalMap(model.UpdateProfileRequest{
+		OrgId:  orgId,
+		UserId: userId,
+		Email:  "member@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynMock.profiles[orgId+"/"+userId] = member
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	addOrgMember(t, dynMock, "org-1", orgInviteeId)
+	dir := &fakeDirectory{orgs: map[string]string{}}
+	ps := ProfileServer{Directory: dir}
+	dynMock.apiKeys["key-1"] = map[string]types.AttributeValue{
+		"KeyId":  &types.AttributeValueMemberS{Value: "key-1"},
+		"UserId": &types.AttributeValueMemberS{Value: orgInviteeId},
+	}
+
+	before := time.Now().UTC().Truncate(time.Second)
+	resp, err := ps.RevokeUserTokens(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.RevokeUserTokensRequest{
+		UserId: orgInviteeId,
+		Reason: revocationReason,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.NotBefore.AsTime().Before(before) {
+		t.Fatalf("expected watermark of now, got %v", resp.NotBefore.AsTime())
+	}
+	if len(dynMock.revocations) != 1 {
+		t.Fatalf("expected one revocation, got %d", len(dynMock.revocations))
+	}
+	var revocation model.Revocation
+	if err := attributevalue.UnmarshalMap(dynMock.revocations[0], &revocation); err != nil {
+		t.Fatal(err)
+	}
+	if revocation.UserId != orgInviteeId || revocation.RevocationId != model.RevocationWatermark ||
+		revocation.NotBefore == nil || revocation.RevokedBy != orgAdminId || revocation.Ttl != 0 {
+		t.Fatalf("unexpected revocation: %+v", revocation)
+	}
+	if len(dir.signedOut) != 1 || dir.signedOut[0] != orgInviteeId {
+		t.Fatalf("expected member to be signed out, got %v", dir.signedOut)
+	}
+	if _, revoked := dynMock.apiKeys["key-1"]["RevokedAt"]; !revoked {
+		t.Fatal("expected the member's api keys to be revoked")
+	}
+	if len(dynMock.history) != 1 || attrS(dynMock.history[0], "Action") != model.HistoryTokensRevoked {
+		t.Fatal("expected revocation in the member's history")
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	addOrgMember(t, dynMock, "org-1", orgInviteeId)
+	ps := ProfileServer{RevokedTokenTtl: time.Hour}
+
+	resp, err := ps.RevokeToken(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.RevokeTokenRequest{
+		UserId:  orgInviteeId,
+		TokenId: "jti-1",
+		Reason:  revocationReason,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var revocation model.Revocation
+	if err := attributevalue.UnmarshalMap(dynMock.revocations[0], &revocation); err != nil {
+		t.Fatal(err)
+	}
+	if revocation.RevocationId != model.TokenRevocationId("jti-1") || revocation.TokenId != "jti-1" || revocation.NotBefore != nil {
+		t.Fatalf("unexpected revocation: %+v", revocation)
+	}
+	if revocation.Ttl != resp.ExpiresAt.AsTime().Unix() || time.Until(resp.ExpiresAt.AsTime()) > time.Hour {
+		t.Fatalf("expected revocation to expire within the configured ttl, got %v", resp.ExpiresAt.AsTime())
+	}
+}
+
+func TestRevokeTokensRequiresOrgAdmin(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	addOrgMember(t, dynMock, "org-1", orgInviteeId)
+	addOrgMember(t, dynMock, "org-2", orgInviteeId[:35]+"C")
+	ps := ProfileServer{}
+
+	_, err := ps.RevokeUserTokens(orgCtx(model.User{Id: orgInviteeId, OrgId: "org-1"}), &profile.RevokeUserTokensRequest{
+		UserId: orgAdminId,
+		Reason: revocationReason,
+	})
+	if errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected permission denied for a member, got %v", err)
+	}
+
+	_, err = ps.RevokeUserTokens(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.RevokeUserTokensRequest{
+		UserId: orgInviteeId[:35] + "C",
+		Reason: revocationReason,
+	})
+	if errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected not found for a user of another org, got %v", err)
+	}
+
+	if len(dynMock.revocations) != 0 {
+		t.Fatal("expected nothing to be revoked")
+	}
+}
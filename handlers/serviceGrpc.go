@@ -0,0 +1,47 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+)
+
+func (o *ProfileServer) LookupProfile(ctx context.Context, req *profile.LookupProfileRequest) (*profile.ReadProfileResponse, error) {
+	service, err := principal.RequireService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("lookup handler could not validate request: %w", err)
+	}
+
+	log.DebugfCtx(ctx, "service looking up profile - %s - %s - %s", service.Name, req.OrgId, req.UserId)
+	body, err := dba.Profiles.ForOrg(req.OrgId).ReadProfile(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup handler could not read profile: %w", err)
+	}
+
+	response := conversions.ConvertReadProfileToProto(body)
+	return &response, nil
+}
@@ -0,0 +1,58 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+func serviceCtx() context.Context {
+	ctx := ctxlogrus.ToContext(context.TODO(), logrus.NewEntry(logrus.New()))
+	return context.WithValue(ctx, model.ServiceCtxKey, model.Service{Name: "order-manager", Subject: "spiffe://ib/order-manager"})
+}
+
+func TestLookupProfile(t *testing.T) {
+	dynMock := setupOrgTest(t)
+	seedDuplicates(t, dynMock)
+	ps := ProfileServer{}
+
+	resp, err := ps.LookupProfile(serviceCtx(), &profile.LookupProfileRequest{OrgId: "org-1", UserId: personalId})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.UserId != personalId || resp.Email != "jane@personal.com" {
+		t.Fatalf("unexpected profile: %v", resp)
+	}
+
+	_, err = ps.LookupProfile(serviceCtx(), &profile.LookupProfileRequest{OrgId: "org-2", UserId: personalId})
+	if errs.ToStatus(err).Code() != codes.NotFound {
+		t.Fatalf("expected NotFound in another org, got %v", err)
+	}
+
+	_, err = ps.LookupProfile(orgCtx(model.User{Id: orgAdminId, OrgId: "org-1"}), &profile.LookupProfileRequest{OrgId: "org-1", UserId: personalId})
+	if errs.ToStatus(err).Code() != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a user, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase-samples/ib-usermgr-go/conversions"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (o *ProfileServer) ListMySessions(ctx context.Context, req *profile.ListMySessionsRequest) (*profile.ListMySessionsResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.DebugfCtx(ctx, "listing sessions: %s", authedUser.Id)
+	sessions, err := dba.Repo.ListSessions(ctx, authedUser.Id)
+	if err != nil {
+		return nil, fmt.Errorf("session handler could not list sessions: %w", err)
+	}
+
+	return &profile.ListMySessionsResponse{
+		Sessions: conversions.ConvertSessionsToProto(sessions, authedUser.SessionId),
+	}, nil
+}
+
+func (o *ProfileServer) RevokeSession(ctx context.Context, req *profile.RevokeSessionRequest) (*profile.RevokeSessionResponse, error) {
+	authedUser, err := principal.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("session handler could not validate request: %w", err)
+	}
+
+	if req.GetOtherSessions() {
+		if authedUser.SessionId == "" {
+			return nil, status.Error(codes.FailedPrecondition, "current session is unknown, cannot keep it signed in")
+		}
+
+		log.DebugfCtx(ctx, "revoking other sessions: %s - %s", authedUser.Id, authedUser.SessionId)
+		revoked, err := dba.Repo.RevokeOtherSessions(ctx, authedUser.Id, authedUser.SessionId)
+		if err != nil {
+			return nil, fmt.Errorf("session handler could not revoke sessions: %w", err)
+		}
+		return &profile.RevokeSessionResponse{
+			Revoked: conversions.ConvertSessionsToProto(revoked, authedUser.SessionId),
+		}, nil
+	}
+
+	log.DebugfCtx(ctx, "revoking session: %s - %s", authedUser.Id, req.GetSessionId())
+	session, err := dba.Repo.RevokeSession(ctx, authedUser.Id, req.GetSessionId())
+	if err != nil {
+		return nil, fmt.Errorf("session handler could not revoke session: %w", err)
+	}
+
+	return &profile.RevokeSessionResponse{
+		Revoked: []*profile.Session{conversions.ConvertSessionToProto(session, authedUser.SessionId)},
+	}, nil
+}
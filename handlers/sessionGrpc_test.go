@@ -0,0 +1,82 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func setupSessionRepo() {
+	app := config.AppConfig{
+		SessionTableName: "Session",
+	}
+	dba.NewDBA(&dba.DynamoRepository{
+		App: &app,
+		Svc: new(DynamoMock),
+	})
+}
+
+func TestListMySessionsHandler(t *testing.T) {
+	setupSessionRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", SessionId: "current"})
+
+	ps := ProfileServer{}
+	resp, err := ps.ListMySessions(ctx, &profile.ListMySessionsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected list sessions error: %v", err)
+	}
+
+	if len(resp.Sessions) != 2 {
+		t.Fatalf("expected two sessions, got %d", len(resp.Sessions))
+	}
+	for _, s := range resp.Sessions {
+		if s.Current != (s.SessionId == "current") {
+			t.Fatalf("unexpected current flag on session %s", s.SessionId)
+		}
+	}
+}
+
+func TestRevokeSessionHandlerInvalid(t *testing.T) {
+	setupSessionRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123", SessionId: "current"})
+
+	ps := ProfileServer{}
+	if _, err := ps.RevokeSession(ctx, &profile.RevokeSessionRequest{}); err == nil {
+		t.Fatal("expected validation error without a target")
+	}
+}
+
+func TestRevokeOtherSessionsRequiresCurrentSession(t *testing.T) {
+	setupSessionRepo()
+	ctx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "123"})
+
+	ps := ProfileServer{}
+	_, err := ps.RevokeSession(ctx, &profile.RevokeSessionRequest{
+		Target: &profile.RevokeSessionRequest_OtherSessions{OtherSessions: true},
+	})
+	if errs.ToStatus(err).Code() != codes.FailedPrecondition {
+		t.Fatalf("expected failed precondition, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+const (
+	// SecretHeader carries the shared secret that authenticates calls to the
+	// HTTP hook endpoints
+	SecretHeader = "X-Hook-Secret"
+
+	// PostConfirmationPath is where the gateway serves the PostConfirmation hook
+	PostConfirmationPath = "/v1/hooks/cognito/post-confirmation"
+
+	triggerConfirmSignUp = "PostConfirmation_ConfirmSignUp"
+	subAttribute         = "sub"
+	maxEventBytes        = 64 << 10
+
+	// userStatusAttribute still reads FORCE_CHANGE_PASSWORD when a user an
+	// administrator created confirms by replacing their temporary password
+	userStatusAttribute = "cognito:user_status"
+	statusAdminCreated  = "FORCE_CHANGE_PASSWORD"
+)
+
+var (
+	ErrInvalidSecret = errs.New(errs.Unauthenticated, "INVALID_HOOK_SECRET", "missing or invalid hook secret")
+	ErrInvalidEvent  = errs.New(errs.InvalidArgument, "INVALID_HOOK_EVENT", "invalid post confirmation event")
+)
+
+// PostConfirmation creates the profile of a user once they confirm their
+// Cognito sign up, in a pending organization unless an administrator created
+// them in one. Cognito can deliver the trigger more than once, so a profile
+// that already exists counts as success.
+type PostConfirmation struct {
+	Repo *dba.DynamoRepository
+	// Profiles is where the profile is created
+	Profiles dba.ProfileStore
+	// Secret must be sent in SecretHeader by callers of the HTTP endpoint,
+	// the Lambda entrypoint is authenticated by Cognito invoking it
+	Secret string
+}
+
+// Handle creates the profile from the event's user attributes and returns the
+// event unchanged, as Cognito expects from a trigger
+func (h *PostConfirmation) Handle(ctx context.Context, event events.CognitoEventUserPoolsPostConfirmation) (events.CognitoEventUserPoolsPostConfirmation, error) {
+	// the trigger also fires when a forgotten password is reset
+	if event.TriggerSource != triggerConfirmSignUp {
+		return event, nil
+	}
+
+	attributes := event.Request.UserAttributes
+	userId := attributes[subAttribute]
+	if userId == "" {
+		return event, ErrInvalidEvent.With("userName", event.UserName)
+	}
+
+	// users signing up on their own choose their attributes, so they are
+	// kept in a pending organization until they accept an invitation. Only
+	// an administrator creating the user can place them in one, and from
+	// then on the membership decides the organization.
+	orgId := model.PendingOrgId(userId)
+	if attributes[userStatusAttribute] == statusAdminCreated && attributes[auth.OrgIdAttribute] != "" {
+		orgId = attributes[auth.OrgIdAttribute]
+		if model.IsPendingOrg(orgId) {
+			return event, ErrInvalidEvent.With("orgId", orgId)
+		}
+	} else if attributes[auth.OrgIdAttribute] != "" {
+		log.DebugfCtx(ctx, "ignoring organization chosen at sign up: %s - %s", userId, attributes[auth.OrgIdAttribute])
+	}
+
+	if _, err := h.Repo.AddMembership(ctx, model.Membership{
+		UserId:   userId,
+		OrgId:    orgId,
+		JoinedAt: time.Now().UTC(),
+	}); err != nil {
+		return event, fmt.Errorf("post confirmation hook could not record membership: %w", err)
+	}
+
+	log.DebugfCtx(ctx, "creating confirmed user: %s - %s", orgId, userId)
+	_, err := h.Profiles.ForOrg(orgId).CreateProfile(ctx, userId, model.UpdateProfileRequest{
+		Email:    attributes[auth.EmailAttribute],
+		Name:     attributes[auth.NameAttribute],
+		UserName: event.UserName,
+	})
+	if errors.Is(err, dba.ErrProfileExists) {
+		return event, nil
+	}
+	if err != nil {
+		return event, fmt.Errorf("post confirmation hook could not create profile: %w", err)
+	}
+
+	if _, err := h.Repo.RecordHistory(ctx, model.HistoryEvent{
+		UserId:  userId,
+		OrgId:   orgId,
+		Action:  model.HistoryProfileCreated,
+		ActorId: userId,
+		Details: map[string]string{"trigger": event.TriggerSource},
+	}); err != nil {
+		log.WarnfCtx(ctx, "could not record profile creation: %v", err)
+	}
+
+	return event, nil
+}
+
+// ServeHTTP accepts the trigger payload over HTTP for user pools that call
+// out through a relay rather than invoking the Lambda entrypoint
+func (h *PostConfirmation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Secret == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(SecretHeader)), []byte(h.Secret)) != 1 {
+		errs.GatewayErrorHandler(r.Context(), nil, nil, w, r, ErrInvalidSecret)
+		return
+	}
+
+	var event events.CognitoEventUserPoolsPostConfirmation
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEventBytes)).Decode(&event); err != nil {
+		errs.GatewayErrorHandler(r.Context(), nil, nil, w, r, ErrInvalidEvent.Wrap(err))
+		return
+	}
+
+	event, err := h.Handle(r.Context(), event)
+	if err != nil {
+		errs.GatewayErrorHandler(r.Context(), nil, nil, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		log.Debugf("could not write post confirmation response: %v", err)
+	}
+}
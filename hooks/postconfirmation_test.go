@@ -0,0 +1,276 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/coinbase-samples/ib-usermgr-go/config"
+	"github.com/coinbase-samples/ib-usermgr-go/dba"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+// HookDynamoMock keeps profiles and memberships in memory, enforcing the
+// create conditions
+type HookDynamoMock struct {
+	profiles    map[string]map[string]types.AttributeValue
+	memberships map[string]map[string]types.AttributeValue
+	history     []map[string]types.AttributeValue
+}
+
+func attrS(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
+
+func (m *HookDynamoMock) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: m.profiles[attrS(params.Key, "OrgId")+"/"+attrS(params.Key, "UserId")]}, nil
+}
+
+func (m *HookDynamoMock) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	switch *params.TableName {
+	case "History":
+		m.history = append(m.history, params.Item)
+		return &dynamodb.PutItemOutput{}, nil
+	case "Membership":
+		existing, ok := m.memberships[attrS(params.Item, "UserId")]
+		if ok && attrS(existing, "OrgId") != attrS(params.Item, "OrgId") {
+			return nil, &types.ConditionalCheckFailedException{}
+		}
+		m.memberships[attrS(params.Item, "UserId")] = params.Item
+		return &dynamodb.PutItemOutput{}, nil
+	}
+	key := attrS(params.Item, "OrgId") + "/" + attrS(params.Item, "UserId")
+	if _, ok := m.profiles[key]; ok && params.ConditionExpression != nil {
+		return nil, &types.ConditionalCheckFailedException{}
+	}
+	m.profiles[key] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *HookDynamoMock) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (m *HookDynamoMock) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return &dynamodb.QueryOutput{}, nil
+}
+
+func (m *HookDynamoMock) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *HookDynamoMock) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func newTestHook() (*PostConfirmation, *HookDynamoMock) {
+	mock := &HookDynamoMock{
+		profiles:    map[string]map[string]types.AttributeValue{},
+		memberships: map[string]map[string]types.AttributeValue{},
+	}
+	repo := &dba.DynamoRepository{
+		App: &config.AppConfig{ProfileTableName: "Profile", HistoryTableName: "History", MembershipTableName: "Membership"},
+		Svc: mock,
+	}
+	return &PostConfirmation{Repo: repo, Profiles: repo, Secret: "s3cret"}, mock
+}
+
+func confirmSignUp(attributes map[string]string) events.CognitoEventUserPoolsPostConfirmation {
+	event := events.CognitoEventUserPoolsPostConfirmation{}
+	event.TriggerSource = triggerConfirmSignUp
+	event.UserName = "bob"
+	event.Request.UserAttributes = attributes
+	return event
+}
+
+// confirmedUser was created by an administrator in org-1
+var confirmedUser = map[string]string{
+	"sub":                 "123",
+	"email":               "bob@example.com",
+	"name":                "Bob Ross",
+	"custom:org_id":       "org-1",
+	"cognito:user_status": "FORCE_CHANGE_PASSWORD",
+}
+
+func TestPostConfirmationCreatesProfile(t *testing.T) {
+	hook, mock := newTestHook()
+
+	if _, err := hook.Handle(context.Background(), confirmSignUp(confirmedUser)); err != nil {
+		t.Fatal(err)
+	}
+
+	item, ok := mock.profiles["org-1/123"]
+	if !ok {
+		t.Fatal("expected a profile to be created")
+	}
+	if attrS(item, "Email") != "bob@example.com" || attrS(item, "Name") != "Bob Ross" || attrS(item, "UserName") != "bob" {
+		t.Fatalf("unexpected profile %v", item)
+	}
+	if len(mock.history) != 1 {
+		t.Fatalf("expected the creation to be recorded, got %d events", len(mock.history))
+	}
+	if attrS(mock.memberships["123"], "OrgId") != "org-1" {
+		t.Fatalf("expected the user to become a member, got %v", mock.memberships)
+	}
+}
+
+func TestPostConfirmationIsIdempotent(t *testing.T) {
+	hook, mock := newTestHook()
+
+	for i := 0; i < 2; i++ {
+		if _, err := hook.Handle(context.Background(), confirmSignUp(confirmedUser)); err != nil {
+			t.Fatalf("delivery %d: %v", i+1, err)
+		}
+	}
+
+	if len(mock.profiles) != 1 || len(mock.history) != 1 {
+		t.Fatalf("expected one profile and one event, got %d and %d", len(mock.profiles), len(mock.history))
+	}
+}
+
+func TestPostConfirmationSkips(t *testing.T) {
+	hook, mock := newTestHook()
+
+	forgotPassword := confirmSignUp(confirmedUser)
+	forgotPassword.TriggerSource = "PostConfirmation_ConfirmForgotPassword"
+	if _, err := hook.Handle(context.Background(), forgotPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mock.profiles) != 0 {
+		t.Fatalf("expected no profiles, got %v", mock.profiles)
+	}
+}
+
+func TestPostConfirmationWithoutOrganization(t *testing.T) {
+	hook, mock := newTestHook()
+
+	if _, err := hook.Handle(context.Background(), confirmSignUp(map[string]string{"sub": "456", "email": "ann@example.com"})); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := model.PendingOrgId("456")
+	if item, ok := mock.profiles[pending+"/456"]; !ok || attrS(item, "Email") != "ann@example.com" {
+		t.Fatalf("expected a profile in the pending organization, got %v", mock.profiles)
+	}
+	if attrS(mock.memberships["456"], "OrgId") != pending {
+		t.Fatalf("expected a pending membership, got %v", mock.memberships)
+	}
+}
+
+func TestPostConfirmationIgnoresOrganizationChosenAtSignUp(t *testing.T) {
+	hook, mock := newTestHook()
+
+	for _, orgId := range []string{"org-1", model.PendingOrgId("123")} {
+		signUp := map[string]string{"sub": "456", "email": "eve@example.com", "custom:org_id": orgId, "cognito:user_status": "CONFIRMED"}
+		if _, err := hook.Handle(context.Background(), confirmSignUp(signUp)); err != nil {
+			t.Fatal(err)
+		}
+
+		pending := model.PendingOrgId("456")
+		if _, ok := mock.profiles[pending+"/456"]; !ok || len(mock.profiles) != 1 {
+			t.Fatalf("%s: expected a profile in the pending organization only, got %v", orgId, mock.profiles)
+		}
+		if attrS(mock.memberships["456"], "OrgId") != pending {
+			t.Fatalf("%s: expected a pending membership, got %v", orgId, mock.memberships)
+		}
+	}
+}
+
+func TestPostConfirmationRejectsPendingOrganization(t *testing.T) {
+	hook, mock := newTestHook()
+
+	adminCreated := map[string]string{"sub": "456", "custom:org_id": model.PendingOrgId("123"), "cognito:user_status": "FORCE_CHANGE_PASSWORD"}
+	if _, err := hook.Handle(context.Background(), confirmSignUp(adminCreated)); !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("expected invalid event, got %v", err)
+	}
+	if len(mock.profiles) != 0 || len(mock.memberships) != 0 {
+		t.Fatalf("expected nothing to be written, got %v and %v", mock.profiles, mock.memberships)
+	}
+}
+
+func TestPostConfirmationMissingSub(t *testing.T) {
+	hook, _ := newTestHook()
+
+	_, err := hook.Handle(context.Background(), confirmSignUp(map[string]string{"custom:org_id": "org-1"}))
+	if !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("expected invalid event, got %v", err)
+	}
+}
+
+func TestPostConfirmationHttp(t *testing.T) {
+	hook, mock := newTestHook()
+	body, err := json.Marshal(confirmSignUp(confirmedUser))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		status int
+	}{
+		{"missing secret", "", http.StatusUnauthorized},
+		{"wrong secret", "guess", http.StatusUnauthorized},
+		{"valid secret", "s3cret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, PostConfirmationPath, bytes.NewReader(body))
+			if tt.secret != "" {
+				req.Header.Set(SecretHeader, tt.secret)
+			}
+			w := httptest.NewRecorder()
+
+			hook.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if _, ok := mock.profiles["org-1/123"]; !ok {
+		t.Fatal("expected a profile to be created")
+	}
+}
+
+func TestPostConfirmationHttpInvalidBody(t *testing.T) {
+	hook, _ := newTestHook()
+	req := httptest.NewRequest(http.MethodPost, PostConfirmationPath, bytes.NewReader([]byte("{")))
+	req.Header.Set(SecretHeader, "s3cret")
+	w := httptest.NewRecorder()
+
+	hook.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
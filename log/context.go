@@ -44,6 +44,12 @@ func ToContext(ctx context.Context, entry *Entry) context.Context {
 	return context.WithValue(ctx, LogCtxKey, entry)
 }
 
+// AddFields returns a context whose entry carries fields on every line logged
+// through it, leaving the entry of the parent context untouched
+func AddFields(ctx context.Context, fields Fields) context.Context {
+	return ToContext(ctx, &Entry{l: Extract(ctx).l.WithFields(logrus.Fields(fields))})
+}
+
 func Extract(ctx context.Context) *Entry {
 	l, ok := ctx.Value(LogCtxKey).(*Entry)
 	if !ok || l == nil {
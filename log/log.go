@@ -19,6 +19,7 @@ package log
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/coinbase-samples/ib-usermgr-go/config"
 	"github.com/sirupsen/logrus"
@@ -32,12 +33,16 @@ func Init(app config.AppConfig) {
 	logLevel, _ := logrus.ParseLevel(app.LogLevel)
 	logger.SetLevel(logLevel)
 	logger.SetReportCaller(true)
-	logger.SetFormatter(&logrus.JSONFormatter{
+	var formatter logrus.Formatter = &logrus.JSONFormatter{
 		CallerPrettyfier: caller(),
 		FieldMap: logrus.FieldMap{
 			logrus.FieldKeyFile: "caller",
 		},
-	})
+	}
+	if app.LogRedact != "false" {
+		formatter = NewRedactingFormatter(formatter, strings.Split(app.LogRedactFields, ",")...)
+	}
+	logger.SetFormatter(formatter)
 	if app.LogToFile == "true" {
 		// open a file
 		f, err := os.OpenFile("testing.log", os.O_APPEND|os.O_CREATE|os.O_RDWR, 0666)
@@ -0,0 +1,205 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	options "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/options/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are log field keys that always carry PII or credentials
+var DefaultRedactedFields = []string{
+	"email",
+	"name",
+	"legalName",
+	"legal_name",
+	"address",
+	"dateOfBirth",
+	"date_of_birth",
+	"ipAddress",
+	"ip_address",
+	"authorization",
+	"grpcgateway-authorization",
+	"x-forwarded-for",
+	"x-impersonation-grant",
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// RedactingFormatter masks PII in an entry before handing it to the
+// wrapped formatter for serialization
+type RedactingFormatter struct {
+	Formatter logrus.Formatter
+	fields    map[string]bool
+}
+
+func NewRedactingFormatter(f logrus.Formatter, extraFields ...string) *RedactingFormatter {
+	fields := make(map[string]bool)
+	for _, k := range append(DefaultRedactedFields, extraFields...) {
+		if k = strings.TrimSpace(k); k != "" {
+			fields[strings.ToLower(k)] = true
+		}
+	}
+	return &RedactingFormatter{Formatter: f, fields: fields}
+}
+
+func (r *RedactingFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	// work on a copy so hooks and other formatters never observe the masking
+	redacted := e.Dup()
+	redacted.Level = e.Level
+	redacted.Caller = e.Caller
+	redacted.Buffer = e.Buffer
+	redacted.Message = emailPattern.ReplaceAllString(e.Message, RedactedValue)
+
+	for k, v := range e.Data {
+		redacted.Data[k] = r.redactValue(k, v)
+	}
+	return r.Formatter.Format(redacted)
+}
+
+func (r *RedactingFormatter) redactValue(key string, v interface{}) interface{} {
+	if r.isRedactedKey(key) {
+		return RedactedValue
+	}
+	switch t := v.(type) {
+	case proto.Message:
+		return RedactProto(t)
+	case string:
+		return emailPattern.ReplaceAllString(t, RedactedValue)
+	case []string:
+		out := make([]string, len(t))
+		for i, s := range t {
+			out[i] = emailPattern.ReplaceAllString(s, RedactedValue)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for mk, mv := range t {
+			out[mk] = r.redactValue(mk, mv)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(t))
+		for mk, mv := range t {
+			out[mk] = fmt.Sprint(r.redactValue(mk, mv))
+		}
+		return out
+	case metadata.MD:
+		return metadata.MD(r.redactValue(key, map[string][]string(t)).(map[string][]string))
+	case map[string][]string:
+		out := make(map[string][]string, len(t))
+		for mk, mv := range t {
+			if r.isRedactedKey(mk) {
+				out[mk] = []string{RedactedValue}
+				continue
+			}
+			out[mk] = r.redactValue(mk, mv).([]string)
+		}
+		return out
+	}
+	return v
+}
+
+func (r *RedactingFormatter) isRedactedKey(key string) bool {
+	k := strings.ToLower(key)
+	if r.fields[k] {
+		return true
+	}
+	// match namespaced keys such as grpc.request.email
+	if i := strings.LastIndex(k, "."); i >= 0 {
+		return r.fields[k[i+1:]]
+	}
+	return false
+}
+
+// RedactProto returns a copy of m with every field annotated as sensitive masked
+func RedactProto(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	c := proto.Clone(m)
+	redactMessage(c.ProtoReflect())
+	return c
+}
+
+func redactMessage(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSensitive(fd) {
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				m.Set(fd, protoreflect.ValueOfString(RedactedValue))
+			} else {
+				m.Clear(fd)
+			}
+			return true
+		}
+		if fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				redactMessage(l.Get(i).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redactMessage(mv.Message())
+					return true
+				})
+			}
+		default:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	if opts == nil {
+		return false
+	}
+	sensitive, _ := proto.GetExtension(opts, options.E_Sensitive).(bool)
+	return sensitive
+}
+
+// Redacted wraps a proto message so that formatting it with %v only ever
+// prints the masked copy
+func Redacted(m proto.Message) fmt.Stringer {
+	return redactedMessage{m}
+}
+
+type redactedMessage struct {
+	m proto.Message
+}
+
+func (r redactedMessage) String() string {
+	return fmt.Sprintf("%v", RedactProto(r.m))
+}
@@ -0,0 +1,145 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	profile "github.com/coinbase-samples/ib-usermgr-go/pkg/pbs/profile/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+var piiValues = []string{
+	"b.ross@coinbase.com",
+	"Bob Ross",
+	"Robert Norman Ross",
+	"123 Happy Way",
+	"10/29/1942",
+	"secretToken",
+}
+
+func newRedactingLogger(buf *bytes.Buffer, extra ...string) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(buf)
+	l.SetLevel(logrus.DebugLevel)
+	l.SetFormatter(NewRedactingFormatter(&logrus.JSONFormatter{}, extra...))
+	return l
+}
+
+func testProfile() *profile.ReadProfileResponse {
+	return &profile.ReadProfileResponse{
+		UserId:      "20032259-738B-40A7-AAD7-306B69AF88D4",
+		Email:       "b.ross@coinbase.com",
+		Name:        "Bob Ross",
+		LegalName:   "Robert Norman Ross",
+		UserName:    "bross",
+		Address:     "123 Happy Way",
+		DateOfBirth: "10/29/1942",
+	}
+}
+
+func assertNoPii(t *testing.T, out string) {
+	t.Helper()
+	for _, v := range piiValues {
+		if strings.Contains(out, v) {
+			t.Fatalf("found pii %q in log output: %s", v, out)
+		}
+	}
+}
+
+func TestRedactKnownFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf)
+
+	l.WithFields(logrus.Fields{
+		"email":                "b.ross@coinbase.com",
+		"legalName":            "Robert Norman Ross",
+		"grpc.request.address": "123 Happy Way",
+		"authorization":        "bearer secretToken",
+		"userId":               "20032259-738B-40A7-AAD7-306B69AF88D4",
+	}).Info("updating profile")
+
+	out := buf.String()
+	assertNoPii(t, out)
+	if !strings.Contains(out, "20032259-738B-40A7-AAD7-306B69AF88D4") {
+		t.Fatalf("expected non pii fields to be kept: %s", out)
+	}
+	if !strings.Contains(out, RedactedValue) {
+		t.Fatalf("expected redaction marker: %s", out)
+	}
+}
+
+func TestRedactProtoField(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf)
+
+	l.WithField("response", testProfile()).Info("returning read profile response")
+
+	out := buf.String()
+	assertNoPii(t, out)
+	if !strings.Contains(out, "bross") {
+		t.Fatalf("expected non sensitive proto fields to be kept: %s", out)
+	}
+}
+
+func TestRedactFormattedMessage(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf)
+
+	l.Debugf("returning read profile response - %v", Redacted(testProfile()))
+	l.Debugf("contact %s for details", "b.ross@coinbase.com")
+
+	assertNoPii(t, buf.String())
+}
+
+func TestRedactMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf)
+
+	md := metadata.Pairs("authorization", "bearer secretToken", "x-request-id", "abc")
+	l.WithField("grpc.metadata", md).Info("request metadata")
+
+	out := buf.String()
+	assertNoPii(t, out)
+	if !strings.Contains(out, "abc") {
+		t.Fatalf("expected non sensitive metadata to be kept: %s", out)
+	}
+}
+
+func TestRedactExtraFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf, "nickname")
+
+	l.WithField("nickname", "Bob Ross").Info("extra field")
+
+	assertNoPii(t, buf.String())
+}
+
+func TestRedactDoesNotMutateEntry(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRedactingLogger(&buf)
+
+	p := testProfile()
+	l.WithField("response", p).Info("returning profile")
+
+	if p.Email != "b.ross@coinbase.com" {
+		t.Fatal("expected original message to be left untouched")
+	}
+}
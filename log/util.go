@@ -34,6 +34,7 @@ func caller() func(*runtime.Frame) (function string, file string) {
 			if ok && (strings.Contains(file, "entry.go") ||
 				strings.Contains(file, "log.go") ||
 				strings.Contains(file, "json_formatter.go") ||
+				strings.Contains(file, "redact.go") ||
 				strings.Contains(file, "logger.go")) {
 				pc, file, line, ok = runtime.Caller(i)
 				funcName = runtime.FuncForPC(pc)
@@ -0,0 +1,53 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// AttributeSyncCognito is the provider of attribute updates bound for the
+// Cognito user pool
+const AttributeSyncCognito = "cognito"
+
+const (
+	// AttributeSyncPending updates are tried again by the retry loop
+	AttributeSyncPending = "pending"
+	// AttributeSyncFailed updates were rejected by the identity provider and
+	// wait for someone to fix the cause and set them back to pending
+	AttributeSyncFailed = "failed"
+)
+
+// AttributeSync is an update of a user's attributes that the identity
+// provider could not be given, kept so it can be retried later or, when the
+// provider rejected it, looked into. Attributes always holds the full set the
+// profile owns, so a newer record replaces an older one for the same user. Reason is the provider's error code for
+// the last failure.
+type AttributeSync struct {
+	Provider   string            `json:"provider"`
+	UserId     string            `json:"userId"`
+	Attributes map[string]string `json:"attributes"`
+	Status     string            `json:"status"`
+	Attempts   int               `json:"attempts"`
+	Reason     string            `json:"reason"`
+	LastError  string            `json:"lastError"`
+	FailedAt   time.Time         `json:"failedAt"`
+}
+
+// IsFailed reports whether the update waits for a replay instead of being
+// retried. Records from before Status existed are pending.
+func (s AttributeSync) IsFailed() bool {
+	return s.Status == AttributeSyncFailed
+}
@@ -0,0 +1,36 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type Consent struct {
+	ConsentId       string    `json:"consentId"`
+	UserId          string    `json:"userId"`
+	DocumentType    string    `json:"documentType"`
+	DocumentVersion string    `json:"documentVersion"`
+	AcceptedAt      time.Time `json:"acceptedAt"`
+	// IpAddress is the connection's address, or the client a trusted proxy
+	// forwarded the call for, never an address the caller named itself
+	IpAddress   string     `json:"ipAddress"`
+	UserAgent   string     `json:"userAgent"`
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty" dynamodbav:",omitempty"`
+}
+
+func (c Consent) IsActive() bool {
+	return c.WithdrawnAt == nil
+}
@@ -0,0 +1,44 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	HistoryProfileCreated       = "profile.created"
+	HistoryProfileUpdated       = "profile.updated"
+	HistoryInvitationAccepted   = "invitation.accepted"
+	HistoryMemberRemoved        = "member.removed"
+	HistoryImpersonationStarted = "impersonation.started"
+	HistoryImpersonationRevoked = "impersonation.revoked"
+	HistoryProfileMerged        = "profile.merged"
+	HistoryTokensRevoked        = "tokens.revoked"
+	HistoryTokenRevoked         = "token.revoked"
+)
+
+// HistoryEvent is an entry in a user's audit trail. UserId is the user the
+// event happened to, ActorId whoever caused it.
+type HistoryEvent struct {
+	UserId               string            `json:"userId"`
+	EventId              string            `json:"eventId"`
+	OrgId                string            `json:"orgId"`
+	Action               string            `json:"action"`
+	ActorId              string            `json:"actorId"`
+	ImpersonationGrantId string            `json:"impersonationGrantId,omitempty" dynamodbav:",omitempty"`
+	OccurredAt           time.Time         `json:"occurredAt"`
+	Details              map[string]string `json:"details,omitempty" dynamodbav:",omitempty"`
+}
@@ -0,0 +1,38 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type ImpersonationGrant struct {
+	GrantId      string     `json:"grantId"`
+	ActorId      string     `json:"actorId"`
+	ActorEmail   string     `json:"actorEmail"`
+	SubjectId    string     `json:"subjectId"`
+	SubjectOrgId string     `json:"subjectOrgId"`
+	SubjectEmail string     `json:"subjectEmail"`
+	Reason       string     `json:"reason"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	RevokedAt    *time.Time `json:"revokedAt,omitempty" dynamodbav:",omitempty"`
+	// Ttl lets DynamoDB drop the grant some time after it has expired
+	Ttl int64 `json:"-"`
+}
+
+func (g ImpersonationGrant) IsActive(now time.Time) bool {
+	return g.RevokedAt == nil && now.Before(g.ExpiresAt)
+}
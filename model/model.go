@@ -19,6 +19,7 @@ package model
 import "time"
 
 type ProfileResponse struct {
+	OrgId       string    `json:"orgId"`
 	UserId      string    `json:"userId"`
 	Email       string    `json:"email"`
 	Name        string    `json:"name"`
@@ -29,9 +30,13 @@ type ProfileResponse struct {
 	DateOfBirth string    `json:"dateOfBirth"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	// MergedInto points a tombstoned duplicate at the profile it was merged into
+	MergedInto string     `json:"mergedInto,omitempty" dynamodbav:",omitempty"`
+	MergedAt   *time.Time `json:"mergedAt,omitempty" dynamodbav:",omitempty"`
 }
 
 type UpdateProfileRequest struct {
+	OrgId       string    `json:"orgId"`
 	UserId      string    `json:"userId"`
 	Email       string    `json:"email"`
 	Name        string    `json:"name"`
@@ -42,13 +47,65 @@ type UpdateProfileRequest struct {
 	DateOfBirth string    `json:"dateOfBirth"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	// MergedInto points a tombstoned duplicate at the profile it was merged into
+	MergedInto string     `json:"mergedInto,omitempty" dynamodbav:",omitempty"`
+	MergedAt   *time.Time `json:"mergedAt,omitempty" dynamodbav:",omitempty"`
+}
+
+func (p ProfileResponse) IsMerged() bool {
+	return p.MergedInto != ""
 }
 
 type UserCtxKeyType string
 
 const UserCtxKey UserCtxKeyType = "user"
 
+// AuthMethod is the kind of credential a request was authenticated with
+const (
+	AuthMethodBearer = "bearer"
+	AuthMethodApiKey = "api_key"
+)
+
 type User struct {
-	Email string `json:"email"`
-	Id    string `json:"id"`
+	Email     string   `json:"email"`
+	Id        string   `json:"id"`
+	OrgId     string   `json:"orgId"`
+	SessionId string   `json:"sessionId"`
+	ApiKeyId  string   `json:"apiKeyId"`
+	Scopes    []string `json:"scopes"`
+	Username  string   `json:"username"`
+	// Groups are the identity provider groups the user belongs to, Roles
+	// the service roles those groups map to
+	Groups     []string  `json:"groups"`
+	Roles      []string  `json:"roles"`
+	AuthMethod string    `json:"authMethod"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	AuthTime   time.Time `json:"authTime"`
+	// Amr are the authentication method references of the sign in
+	Amr []string `json:"amr,omitempty"`
+	// ActorId and ActorEmail identify the support engineer acting as this
+	// user under ImpersonationGrantId, they are empty otherwise
+	ActorId              string `json:"actorId,omitempty"`
+	ActorEmail           string `json:"actorEmail,omitempty"`
+	ImpersonationGrantId string `json:"impersonationGrantId,omitempty"`
+}
+
+func (u User) IsImpersonated() bool {
+	return u.ImpersonationGrantId != ""
+}
+
+// Actor returns the id of whoever is really making the request
+func (u User) Actor() string {
+	if u.IsImpersonated() {
+		return u.ActorId
+	}
+	return u.Id
+}
+
+// ClientInfoCtxKey holds the ClientInfo resolved for the request
+const ClientInfoCtxKey UserCtxKeyType = "clientInfo"
+
+type ClientInfo struct {
+	IpAddress string `json:"ipAddress"`
+	UserAgent string `json:"userAgent"`
 }
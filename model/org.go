@@ -0,0 +1,78 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+
+	// pendingOrgPrefix marks the organization of a user who signed up on
+	// their own, which they leave by accepting an invitation
+	pendingOrgPrefix = "pending:"
+)
+
+type Invitation struct {
+	InvitationId string     `json:"invitationId"`
+	OrgId        string     `json:"orgId"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	TokenHash    string     `json:"-"`
+	InvitedBy    string     `json:"invitedBy"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	AcceptedAt   *time.Time `json:"acceptedAt,omitempty" dynamodbav:",omitempty"`
+	AcceptedBy   string     `json:"acceptedBy,omitempty" dynamodbav:",omitempty"`
+}
+
+func (i Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// Membership is the service's record of the organization a user belongs to.
+// It decides which organization's profiles a user can reach, the identity
+// provider's org attribute only mirrors it.
+type Membership struct {
+	UserId   string    `json:"userId"`
+	OrgId    string    `json:"orgId"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// PendingOrgId is the organization holding the profile of a user who signed
+// up without one, it only ever has that user as its member
+func PendingOrgId(userId string) string {
+	return pendingOrgPrefix + userId
+}
+
+// IsPendingOrg reports whether orgId is the pending organization of a user
+func IsPendingOrg(orgId string) bool {
+	return strings.HasPrefix(orgId, pendingOrgPrefix)
+}
+
+// HasRole reports whether roles contains role
+func HasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
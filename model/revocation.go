@@ -0,0 +1,52 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// RevocationWatermark is the RevocationId of the revocation that cuts off
+// every token of a user signed in before NotBefore. A user has at most one.
+const RevocationWatermark = "watermark"
+
+// Revocation rejects access tokens that are otherwise still valid, either a
+// single token by its jti or every token from sign ins up to NotBefore
+type Revocation struct {
+	UserId       string     `json:"userId"`
+	RevocationId string     `json:"revocationId"`
+	TokenId      string     `json:"tokenId,omitempty" dynamodbav:",omitempty"`
+	NotBefore    *time.Time `json:"notBefore,omitempty" dynamodbav:",omitempty"`
+	Reason       string     `json:"reason"`
+	RevokedBy    string     `json:"revokedBy"`
+	RevokedAt    time.Time  `json:"revokedAt"`
+	// Ttl lets DynamoDB drop token revocations once the token has expired,
+	// watermarks have none and are kept
+	Ttl int64 `json:"-" dynamodbav:",omitempty"`
+}
+
+// TokenRevocationId is the RevocationId of the revocation of a single token
+func TokenRevocationId(tokenId string) string {
+	return "token#" + tokenId
+}
+
+// Revokes reports whether a token with the given jti, from a sign in at
+// signedInAt, is rejected by the revocation
+func (r Revocation) Revokes(tokenId string, signedInAt time.Time) bool {
+	if r.TokenId != "" && r.TokenId == tokenId {
+		return true
+	}
+	return r.NotBefore != nil && !signedInAt.After(*r.NotBefore)
+}
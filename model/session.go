@@ -0,0 +1,34 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+type Session struct {
+	UserId      string     `json:"userId"`
+	SessionId   string     `json:"sessionId"`
+	TokenId     string     `json:"tokenId"`
+	IpAddress   string     `json:"ipAddress"`
+	UserAgent   string     `json:"userAgent"`
+	FirstSeenAt time.Time  `json:"firstSeenAt"`
+	LastSeenAt  time.Time  `json:"lastSeenAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty" dynamodbav:",omitempty"`
+}
+
+func (s Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
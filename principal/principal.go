@@ -0,0 +1,120 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package principal reads the authenticated caller of a request from its
+// context. Handlers should use it rather than asserting on context values,
+// so a request that reached them unauthenticated fails instead of panicking.
+package principal
+
+import (
+	"context"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+)
+
+var (
+	ErrUnauthenticated = errs.New(errs.Unauthenticated, "UNAUTHENTICATED", "request has no authenticated caller")
+	ErrServiceRequired = errs.New(errs.PermissionDenied, "SERVICE_REQUIRED", "method is only available to internal services")
+)
+
+// Principal is the caller a request is attributed to, one of User, ApiKey,
+// Impersonated or Service
+type Principal interface {
+	// Subject is the user id or service name the request acts as
+	Subject() string
+}
+
+// User is a person signed in with a bearer token
+type User struct {
+	model.User
+}
+
+// ApiKey is a programmatic client acting with one of a user's api keys
+type ApiKey struct {
+	model.User
+}
+
+// Impersonated is a support engineer acting as a customer under a grant. The
+// embedded user is the customer, ActorId the engineer.
+type Impersonated struct {
+	model.User
+}
+
+// Service is an internal caller authenticated by its client certificate
+// without a user's token
+type Service struct {
+	model.Service
+}
+
+func (p User) Subject() string         { return p.Id }
+func (p ApiKey) Subject() string       { return p.Id }
+func (p Impersonated) Subject() string { return p.Id }
+func (p Service) Subject() string      { return p.Name }
+
+// FromContext returns the caller of the request. A user is preferred over the
+// service connection the request may have arrived on.
+func FromContext(ctx context.Context) (Principal, bool) {
+	if user, ok := ctx.Value(model.UserCtxKey).(model.User); ok {
+		switch {
+		case user.IsImpersonated():
+			return Impersonated{user}, true
+		case user.ApiKeyId != "":
+			return ApiKey{user}, true
+		default:
+			return User{user}, true
+		}
+	}
+	if service, ok := ServiceFromContext(ctx); ok {
+		return Service{service}, true
+	}
+	return nil, false
+}
+
+// ServiceFromContext returns the internal service the request arrived from,
+// whether or not it also carries a user
+func ServiceFromContext(ctx context.Context) (model.Service, bool) {
+	service, ok := ctx.Value(model.ServiceCtxKey).(model.Service)
+	return service, ok
+}
+
+// RequireUser returns the user the request acts as, whether signed in,
+// through an api key or impersonated, failing with ErrUnauthenticated
+// otherwise
+func RequireUser(ctx context.Context) (model.User, error) {
+	switch p, _ := FromContext(ctx); p := p.(type) {
+	case User:
+		return p.User, nil
+	case ApiKey:
+		return p.User, nil
+	case Impersonated:
+		return p.User, nil
+	}
+	return model.User{}, ErrUnauthenticated
+}
+
+// RequireService returns the internal service the request arrived from,
+// failing with ErrUnauthenticated when there is no caller at all and
+// ErrServiceRequired when the caller is not a service
+func RequireService(ctx context.Context) (model.Service, error) {
+	if service, ok := ServiceFromContext(ctx); ok {
+		return service, nil
+	}
+	if _, ok := FromContext(ctx); ok {
+		return model.Service{}, ErrServiceRequired
+	}
+	return model.Service{}, ErrUnauthenticated
+}
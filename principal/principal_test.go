@@ -0,0 +1,140 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package principal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromContext(t *testing.T) {
+	service := model.Service{Name: "order-manager"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    Principal
+		subject string
+	}{
+		{
+			name:    "signed in user",
+			ctx:     context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "user-1"}),
+			want:    User{model.User{Id: "user-1"}},
+			subject: "user-1",
+		},
+		{
+			name:    "api key",
+			ctx:     context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "user-1", ApiKeyId: "key-1"}),
+			want:    ApiKey{model.User{Id: "user-1", ApiKeyId: "key-1"}},
+			subject: "user-1",
+		},
+		{
+			name:    "impersonated",
+			ctx:     context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "customer-1", ActorId: "support-1", ImpersonationGrantId: "grant-1"}),
+			want:    Impersonated{model.User{Id: "customer-1", ActorId: "support-1", ImpersonationGrantId: "grant-1"}},
+			subject: "customer-1",
+		},
+		{
+			name:    "service",
+			ctx:     context.WithValue(context.Background(), model.ServiceCtxKey, service),
+			want:    Service{service},
+			subject: "order-manager",
+		},
+		{
+			name: "user over a service connection",
+			ctx: context.WithValue(
+				context.WithValue(context.Background(), model.ServiceCtxKey, service),
+				model.UserCtxKey, model.User{Id: "user-1"},
+			),
+			want:    User{model.User{Id: "user-1"}},
+			subject: "user-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromContext(tt.ctx)
+			if !ok {
+				t.Fatal("expected a principal")
+			}
+			if gotType, wantType := typeName(got), typeName(tt.want); gotType != wantType {
+				t.Fatalf("expected a %s principal, got %s", wantType, gotType)
+			}
+			if got.Subject() != tt.subject {
+				t.Fatalf("expected subject %q, got %q", tt.subject, got.Subject())
+			}
+		})
+	}
+}
+
+func typeName(p Principal) string {
+	switch p.(type) {
+	case User:
+		return "user"
+	case ApiKey:
+		return "api key"
+	case Impersonated:
+		return "impersonated"
+	case Service:
+		return "service"
+	}
+	return "unknown"
+}
+
+func TestRequireUser(t *testing.T) {
+	if _, err := RequireUser(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated without a caller, got %v", err)
+	}
+
+	serviceCtx := context.WithValue(context.Background(), model.ServiceCtxKey, model.Service{Name: "order-manager"})
+	if _, err := RequireUser(serviceCtx); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected a service alone to be refused, got %v", err)
+	}
+
+	impersonated := model.User{Id: "customer-1", ActorId: "support-1", ImpersonationGrantId: "grant-1"}
+	user, err := RequireUser(context.WithValue(context.Background(), model.UserCtxKey, impersonated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Id != "customer-1" || user.ActorId != "support-1" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+}
+
+func TestRequireService(t *testing.T) {
+	if _, err := RequireService(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated without a caller, got %v", err)
+	}
+
+	userCtx := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "user-1"})
+	if _, err := RequireService(userCtx); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied for a user, got %v", err)
+	}
+
+	service, err := RequireService(context.WithValue(context.Background(), model.ServiceCtxKey, model.Service{Name: "order-manager"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Name != "order-manager" {
+		t.Fatalf("unexpected service %+v", service)
+	}
+}
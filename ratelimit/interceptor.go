@@ -0,0 +1,98 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/auth"
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+	"github.com/coinbase-samples/ib-usermgr-go/principal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RetryAfterHeader is sent with rejected calls, holding whole seconds
+const RetryAfterHeader = "retry-after"
+
+// anyMethod is the method address buckets are kept under, so one bucket
+// covers every method
+const anyMethod = "*"
+
+var ErrRateLimited = errs.New(errs.ResourceExhausted, "RATE_LIMITED", "too many requests, slow down")
+
+// Interceptor rejects calls over the caller's limit with ResourceExhausted.
+// It must run after the auth middleware so callers are told apart by
+// identity rather than by address.
+func (l *Limiter) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		caller := callerKey(ctx)
+		if allowed, retryAfter := l.Allow(info.FullMethod, caller); !allowed {
+			return nil, reject(ctx, caller, info.FullMethod, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AddressInterceptor rejects calls over the limit of the client address,
+// counting every method against one bucket. It runs before the auth
+// middleware, so a flood of bad credentials is turned away before each one
+// costs an identity provider call.
+func (l *Limiter) AddressInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		caller := addressKey(ctx)
+		if allowed, retryAfter := l.Allow(anyMethod, caller); !allowed {
+			return nil, reject(ctx, caller, info.FullMethod, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// reject tells the caller when to retry and returns the error to fail with
+func reject(ctx context.Context, caller, fullMethod string, retryAfter time.Duration) error {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10))); err != nil {
+		log.DebugfCtx(ctx, "could not set retry-after header: %v", err)
+	}
+	log.DebugfCtx(ctx, "rate limiting caller: %s - %s - %v", caller, fullMethod, retryAfter)
+	return ErrRateLimited.WithRetryAfter(retryAfter)
+}
+
+// callerKey identifies the caller a bucket belongs to. Support engineers
+// impersonating customers spend their own budget, and calls without a
+// principal fall back to the client address.
+func callerKey(ctx context.Context) string {
+	p, _ := principal.FromContext(ctx)
+	switch p := p.(type) {
+	case principal.ApiKey:
+		return "apikey:" + p.ApiKeyId
+	case principal.Impersonated:
+		return "user:" + p.ActorId
+	case principal.User:
+		return "user:" + p.Id
+	case principal.Service:
+		return "service:" + p.Name
+	}
+	return addressKey(ctx)
+}
+
+func addressKey(ctx context.Context) string {
+	return "ip:" + auth.ClientInfoFromContext(ctx).IpAddress
+}
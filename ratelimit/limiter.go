@@ -0,0 +1,205 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit keeps per caller token buckets for each rate limited
+// RPC, so one misbehaving client cannot starve the others
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/log"
+)
+
+// sweepInterval is how often buckets that have refilled are dropped, and
+// fullSweepInterval how often while the limiter is full
+const (
+	sweepInterval     = time.Minute
+	fullSweepInterval = time.Second
+)
+
+// maxBuckets bounds the memory held for callers. Once that many are being
+// limited at the same time new callers are turned away until some refill.
+const maxBuckets = 100000
+
+// Limit allows Requests per Period, all of which may be spent at once
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit reads limits such as "10/m", "300/min", "5/s" or "100/30s"
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("rate limit %q is not of the form <requests>/<period>", s)
+	}
+
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests < 1 {
+		return Limit{}, fmt.Errorf("rate limit %q needs a positive request count", s)
+	}
+
+	var period time.Duration
+	switch parts[1] {
+	case "s", "sec", "second":
+		period = time.Second
+	case "m", "min", "minute":
+		period = time.Minute
+	case "h", "hour":
+		period = time.Hour
+	default:
+		if period, err = time.ParseDuration(parts[1]); err != nil || period <= 0 {
+			return Limit{}, fmt.Errorf("rate limit %q has an invalid period", s)
+		}
+	}
+
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+// perSecond is the rate the bucket refills at
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+}
+
+// refill tops the bucket up for the time passed since it was last used
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed*b.limit.perSecond())
+	b.updated = now
+}
+
+// Limiter holds the limits of each method and a bucket per method and caller
+type Limiter struct {
+	// limits are keyed by full method name or by the bare method name,
+	// e.g. /pkg.pbs.profile.v1.ProfileService/UpdateProfile or UpdateProfile
+	limits   map[string]Limit
+	fallback *Limit
+
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	maxBuckets int
+	lastSweep  time.Time
+	now        func() time.Time
+}
+
+// NewLimiter parses limits keyed by method and the fallback applied to every
+// other method. An empty fallback leaves those methods unlimited.
+func NewLimiter(limits map[string]string, fallback string) (*Limiter, error) {
+	l := &Limiter{
+		limits:     make(map[string]Limit, len(limits)),
+		buckets:    make(map[string]*bucket),
+		maxBuckets: maxBuckets,
+		now:        time.Now,
+	}
+
+	for method, s := range limits {
+		limit, err := ParseLimit(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		l.limits[method] = limit
+	}
+
+	if fallback != "" {
+		limit, err := ParseLimit(fallback)
+		if err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+		l.fallback = &limit
+	}
+
+	return l, nil
+}
+
+// limitFor returns the limit of a method, preferring one configured for the
+// full method name
+func (l *Limiter) limitFor(fullMethod string) (Limit, bool) {
+	if limit, ok := l.limits[fullMethod]; ok {
+		return limit, true
+	}
+	if limit, ok := l.limits[fullMethod[strings.LastIndex(fullMethod, "/")+1:]]; ok {
+		return limit, true
+	}
+	if l.fallback != nil {
+		return *l.fallback, true
+	}
+	return Limit{}, false
+}
+
+// Allow spends a token of the caller's bucket for the method. When the bucket
+// is empty it reports how long until the next token is available.
+func (l *Limiter) Allow(fullMethod, caller string) (bool, time.Duration) {
+	limit, ok := l.limitFor(fullMethod)
+	if !ok {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now, sweepInterval)
+
+	key := fullMethod + "|" + caller
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= l.maxBuckets {
+			if l.sweep(now, fullSweepInterval); len(l.buckets) >= l.maxBuckets {
+				log.Debugf("rate limiter is full, turning away new caller: %s - %s", fullMethod, caller)
+				return false, limit.Period / time.Duration(limit.Requests)
+			}
+		}
+		b = &bucket{limit: limit, tokens: float64(limit.Requests), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / limit.perSecond()
+	return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+}
+
+// sweep drops buckets that have refilled completely, a new bucket would be
+// created in the same state. It runs at most once per interval.
+func (l *Limiter) sweep(now time.Time, interval time.Duration) {
+	if now.Sub(l.lastSweep) < interval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/**
+ * Copyright 2022-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/coinbase-samples/ib-usermgr-go/errs"
+	"github.com/coinbase-samples/ib-usermgr-go/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const updateProfile = "/pkg.pbs.profile.v1.ProfileService/UpdateProfile"
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Limit
+		ok   bool
+	}{
+		{"10/m", Limit{10, time.Minute}, true},
+		{"300/min", Limit{300, time.Minute}, true},
+		{"5/s", Limit{5, time.Second}, true},
+		{"1000/hour", Limit{1000, time.Hour}, true},
+		{"100/30s", Limit{100, 30 * time.Second}, true},
+		{"10", Limit{}, false},
+		{"0/m", Limit{}, false},
+		{"ten/m", Limit{}, false},
+		{"10/fortnight", Limit{}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("%q: unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.in, tt.want, got)
+		}
+	}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestLimiter(t *testing.T, limits map[string]string, fallback string) (*Limiter, *testClock) {
+	t.Helper()
+	l, err := NewLimiter(limits, fallback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &testClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestLimiterBucket(t *testing.T) {
+	l, clock := newTestLimiter(t, map[string]string{"UpdateProfile": "10/m"}, "")
+
+	for i := 0; i < 10; i++ {
+		if ok, _ := l.Allow(updateProfile, "user:1"); !ok {
+			t.Fatalf("expected request %d within the burst to pass", i+1)
+		}
+	}
+
+	ok, retryAfter := l.Allow(updateProfile, "user:1")
+	if ok {
+		t.Fatal("expected the 11th request to be limited")
+	}
+	if retryAfter != 6*time.Second {
+		t.Fatalf("expected to wait 6s for the next token, got %v", retryAfter)
+	}
+
+	if ok, _ := l.Allow(updateProfile, "user:2"); !ok {
+		t.Fatal("expected another caller to have their own bucket")
+	}
+
+	clock.now = clock.now.Add(6 * time.Second)
+	if ok, _ := l.Allow(updateProfile, "user:1"); !ok {
+		t.Fatal("expected a token after waiting")
+	}
+	if ok, _ := l.Allow(updateProfile, "user:1"); ok {
+		t.Fatal("expected only one token to have refilled")
+	}
+}
+
+func TestLimiterMethods(t *testing.T) {
+	l, _ := newTestLimiter(t, map[string]string{"UpdateProfile": "1/m"}, "")
+
+	for i := 0; i < 100; i++ {
+		if ok, _ := l.Allow("/pkg.pbs.profile.v1.ProfileService/ReadProfile", "user:1"); !ok {
+			t.Fatal("expected methods without a limit to be unlimited")
+		}
+	}
+
+	l, _ = newTestLimiter(t, map[string]string{
+		"UpdateProfile": "1/m",
+		updateProfile:   "2/m",
+	}, "1/m")
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow(updateProfile, "user:1"); !ok {
+			t.Fatal("expected the full method limit to win")
+		}
+	}
+	if ok, _ := l.Allow("/pkg.pbs.profile.v1.ProfileService/ReadProfile", "user:1"); !ok {
+		t.Fatal("expected the default limit to allow a first call")
+	}
+	if ok, _ := l.Allow("/pkg.pbs.profile.v1.ProfileService/ReadProfile", "user:1"); ok {
+		t.Fatal("expected the default limit to apply")
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, clock := newTestLimiter(t, map[string]string{"UpdateProfile": "10/m"}, "")
+
+	l.Allow(updateProfile, "user:1")
+	clock.now = clock.now.Add(2 * time.Minute)
+	l.Allow(updateProfile, "user:2")
+
+	if _, ok := l.buckets[updateProfile+"|user:1"]; ok {
+		t.Fatal("expected the refilled bucket to be dropped")
+	}
+	if len(l.buckets) != 1 {
+		t.Fatalf("expected one bucket, got %d", len(l.buckets))
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	l, _ := newTestLimiter(t, map[string]string{"UpdateProfile": "1/m"}, "")
+	intercept := l.Interceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: updateProfile}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &struct{}{}, nil
+	}
+
+	user := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "user-1"})
+	apiKey := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "user-1", ApiKeyId: "key-1"})
+	impersonating := context.WithValue(context.Background(), model.UserCtxKey, model.User{Id: "customer-1", ActorId: "user-1", ImpersonationGrantId: "grant-1"})
+	anonymous := fromAddress(context.Background(), "192.0.2.1")
+
+	for _, ctx := range []context.Context{user, apiKey, anonymous} {
+		if _, err := intercept(ctx, nil, info, handler); err != nil {
+			t.Fatalf("expected the first call of each caller to pass, got %v", err)
+		}
+	}
+
+	// the support engineer spends their own budget while impersonating
+	_, err := intercept(impersonating, nil, info, handler)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected rate limited, got %v", err)
+	}
+	s := errs.ToStatus(err)
+	if s.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected resource exhausted, got %v", s.Code())
+	}
+	if d, ok := errs.RetryAfter(s); !ok || d != time.Minute {
+		t.Fatalf("expected to retry after a minute, got %v", d)
+	}
+}
+
+func fromAddress(ctx context.Context, ip string) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}})
+}
+
+func TestAddressInterceptor(t *testing.T) {
+	l, _ := newTestLimiter(t, nil, "2/m")
+	intercept := l.AddressInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &struct{}{}, nil
+	}
+
+	// nobody is authenticated yet, and every method spends the same bucket
+	client := fromAddress(context.Background(), "192.0.2.1")
+	other := fromAddress(context.Background(), "192.0.2.2")
+	for _, method := range []string{updateProfile, "/pkg.pbs.profile.v1.ProfileService/ReadProfile"} {
+		if _, err := intercept(client, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			t.Fatalf("expected %s to pass, got %v", method, err)
+		}
+	}
+
+	_, err := intercept(client, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.pbs.profile.v1.ProfileService/ListApiKeys"}, handler)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected the address to be rate limited, got %v", err)
+	}
+	if _, err := intercept(other, nil, &grpc.UnaryServerInfo{FullMethod: updateProfile}, handler); err != nil {
+		t.Fatalf("expected another address to pass, got %v", err)
+	}
+
+	unlimited, _ := newTestLimiter(t, nil, "")
+	for i := 0; i < 5; i++ {
+		if _, err := unlimited.AddressInterceptor()(client, nil, &grpc.UnaryServerInfo{FullMethod: updateProfile}, handler); err != nil {
+			t.Fatalf("expected no address limit, got %v", err)
+		}
+	}
+}
+
+func TestAddressInterceptorIgnoresForwardedFor(t *testing.T) {
+	l, _ := newTestLimiter(t, nil, "2/m")
+	intercept := l.AddressInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: updateProfile}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &struct{}{}, nil
+	}
+
+	// a caller that is not a trusted proxy names a new address on every
+	// call, it still spends the bucket of its own
+	var err error
+	for _, forwarded := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+		ctx := metadata.NewIncomingContext(fromAddress(context.Background(), "192.0.2.1"), metadata.Pairs("x-forwarded-for", forwarded))
+		_, err = intercept(ctx, nil, info, handler)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected a changing x-forwarded-for to keep the limit, got %v", err)
+	}
+	if len(l.buckets) != 1 {
+		t.Fatalf("expected one bucket, got %d", len(l.buckets))
+	}
+}
+
+func TestLimiterIsBounded(t *testing.T) {
+	l, clock := newTestLimiter(t, map[string]string{"UpdateProfile": "1/m"}, "")
+	l.maxBuckets = 2
+
+	for _, caller := range []string{"ip:192.0.2.1", "ip:192.0.2.2"} {
+		if allowed, _ := l.Allow(updateProfile, caller); !allowed {
+			t.Fatalf("expected %s to pass", caller)
+		}
+	}
+	if allowed, wait := l.Allow(updateProfile, "ip:192.0.2.3"); allowed || wait != time.Minute {
+		t.Fatalf("expected a new caller to be turned away while full, got %v %v", allowed, wait)
+	}
+	if len(l.buckets) != 2 {
+		t.Fatalf("expected the limiter to stay at its cap, got %d", len(l.buckets))
+	}
+
+	// once the buckets refill they make room again
+	clock.now = clock.now.Add(time.Minute)
+	if allowed, _ := l.Allow(updateProfile, "ip:192.0.2.3"); !allowed {
+		t.Fatal("expected a new caller to pass once buckets refilled")
+	}
+}
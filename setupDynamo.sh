@@ -1,23 +1,155 @@
 BASE_URL=http://localhost:4566
 PROFILE_TABLENAME=Profile
+CONSENT_TABLENAME=Consent
+SESSION_TABLENAME=Session
+APIKEY_TABLENAME=ApiKey
+INVITATION_TABLENAME=Invitation
+MEMBERSHIP_TABLENAME=Membership
+IMPERSONATION_TABLENAME=Impersonation
+HISTORY_TABLENAME=History
+ATTRIBUTE_SYNC_TABLENAME=AttributeSync
+REVOCATION_TABLENAME=Revocation
 
 aws dynamodb --endpoint-url=$BASE_URL create-table \
     --table-name $PROFILE_TABLENAME \
     --attribute-definitions \
+        AttributeName=OrgId,AttributeType=S \
         AttributeName=UserId,AttributeType=S \
+    --key-schema \
+        AttributeName=OrgId,KeyType=HASH \
+        AttributeName=UserId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $CONSENT_TABLENAME \
+    --attribute-definitions \
+        AttributeName=UserId,AttributeType=S \
+        AttributeName=ConsentId,AttributeType=S \
+    --key-schema \
+        AttributeName=UserId,KeyType=HASH \
+        AttributeName=ConsentId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $SESSION_TABLENAME \
+    --attribute-definitions \
+        AttributeName=UserId,AttributeType=S \
+        AttributeName=SessionId,AttributeType=S \
     --key-schema \
         AttributeName=UserId,KeyType=HASH \
+        AttributeName=SessionId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $APIKEY_TABLENAME \
+    --attribute-definitions \
+        AttributeName=KeyId,AttributeType=S \
+        AttributeName=UserId,AttributeType=S \
+    --key-schema \
+        AttributeName=KeyId,KeyType=HASH \
+    --global-secondary-indexes \
+        'IndexName=UserId-index,KeySchema=[{AttributeName=UserId,KeyType=HASH}],Projection={ProjectionType=ALL},ProvisionedThroughput={ReadCapacityUnits=10,WriteCapacityUnits=5}' \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $INVITATION_TABLENAME \
+    --attribute-definitions \
+        AttributeName=InvitationId,AttributeType=S \
+    --key-schema \
+        AttributeName=InvitationId,KeyType=HASH \
 --provisioned-throughput \
         ReadCapacityUnits=10,WriteCapacityUnits=5
 
 
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $MEMBERSHIP_TABLENAME \
+    --attribute-definitions \
+        AttributeName=UserId,AttributeType=S \
+    --key-schema \
+        AttributeName=UserId,KeyType=HASH \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $IMPERSONATION_TABLENAME \
+    --attribute-definitions \
+        AttributeName=GrantId,AttributeType=S \
+        AttributeName=ActorId,AttributeType=S \
+    --key-schema \
+        AttributeName=GrantId,KeyType=HASH \
+    --global-secondary-indexes \
+        'IndexName=ActorId-index,KeySchema=[{AttributeName=ActorId,KeyType=HASH}],Projection={ProjectionType=ALL},ProvisionedThroughput={ReadCapacityUnits=10,WriteCapacityUnits=5}' \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+aws dynamodb --endpoint-url=$BASE_URL update-time-to-live \
+    --table-name $IMPERSONATION_TABLENAME \
+    --time-to-live-specification Enabled=true,AttributeName=Ttl
+
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $HISTORY_TABLENAME \
+    --attribute-definitions \
+        AttributeName=UserId,AttributeType=S \
+        AttributeName=EventId,AttributeType=S \
+    --key-schema \
+        AttributeName=UserId,KeyType=HASH \
+        AttributeName=EventId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $ATTRIBUTE_SYNC_TABLENAME \
+    --attribute-definitions \
+        AttributeName=Provider,AttributeType=S \
+        AttributeName=UserId,AttributeType=S \
+    --key-schema \
+        AttributeName=Provider,KeyType=HASH \
+        AttributeName=UserId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+aws dynamodb --endpoint-url=$BASE_URL create-table \
+    --table-name $REVOCATION_TABLENAME \
+    --attribute-definitions \
+        AttributeName=UserId,AttributeType=S \
+        AttributeName=RevocationId,AttributeType=S \
+    --key-schema \
+        AttributeName=UserId,KeyType=HASH \
+        AttributeName=RevocationId,KeyType=RANGE \
+--provisioned-throughput \
+        ReadCapacityUnits=10,WriteCapacityUnits=5
+
+aws dynamodb --endpoint-url=$BASE_URL update-time-to-live \
+    --table-name $REVOCATION_TABLENAME \
+    --time-to-live-specification Enabled=true,AttributeName=Ttl
+
+
 aws --endpoint-url=$BASE_URL dynamodb put-item \
     --table-name $PROFILE_TABLENAME \
     --item \
-        '{"UserId": {"S": "37d10e18-34a2-4bd2-b7bc-b8e6dd6358f1"}, "Email": {"S": "demo0@coinbase.com"}, "Name": {"S": "Ted Robinson"}, "LegalName": {"S": "Ted Robinson"}, "UserName": {"S": "d0"}, "Address": {"S": "Some Mountain, Canada"}, "DateOfBirth": {"S": "10/22/2003"}}'
+        '{"OrgId": {"S": "demo-org"}, "UserId": {"S": "37d10e18-34a2-4bd2-b7bc-b8e6dd6358f1"}, "Email": {"S": "demo0@coinbase.com"}, "Name": {"S": "Ted Robinson"}, "LegalName": {"S": "Ted Robinson"}, "UserName": {"S": "d0"}, "Roles": {"L": [{"S": "admin"}]}, "Address": {"S": "Some Mountain, Canada"}, "DateOfBirth": {"S": "10/22/2003"}}'
 
 aws --endpoint-url=$BASE_URL dynamodb put-item \
     --table-name $PROFILE_TABLENAME \
     --item \
-        '{"UserId": {"S": "4f5a6336-8101-4634-a458-73b7f6fcf49f"}, "Email": {"S": "demo1@coinbase.com"}, "Name": {"S": "Henry Thomas"}, "LegalName": {"S": "Henry Thomas"}, "UserName": {"S": "d1"}, "Address": {"S": "Some Mountain, Canada"}, "DateOfBirth": {"S": "10/22/2003"}}'
+        '{"OrgId": {"S": "demo-org"}, "UserId": {"S": "4f5a6336-8101-4634-a458-73b7f6fcf49f"}, "Email": {"S": "demo1@coinbase.com"}, "Name": {"S": "Henry Thomas"}, "LegalName": {"S": "Henry Thomas"}, "UserName": {"S": "d1"}, "Address": {"S": "Some Mountain, Canada"}, "DateOfBirth": {"S": "10/22/2003"}}'
 
+aws --endpoint-url=$BASE_URL dynamodb put-item \
+    --table-name $MEMBERSHIP_TABLENAME \
+    --item \
+        '{"UserId": {"S": "37d10e18-34a2-4bd2-b7bc-b8e6dd6358f1"}, "OrgId": {"S": "demo-org"}}'
+
+aws --endpoint-url=$BASE_URL dynamodb put-item \
+    --table-name $MEMBERSHIP_TABLENAME \
+    --item \
+        '{"UserId": {"S": "4f5a6336-8101-4634-a458-73b7f6fcf49f"}, "OrgId": {"S": "demo-org"}}'